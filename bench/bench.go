@@ -0,0 +1,212 @@
+// Package bench reads the output of go test -bench, keeps a history of
+// the results and finds regressions between runs.
+//
+// The history is a JSON lines file with one Record per benchmark and
+// run, a run being identified by its time and the git commit it
+// measured.
+package bench
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result is the measurement of one benchmark, averaged over -count runs.
+type Result struct {
+    Package string `json:"package"`
+    Name string `json:"name"`
+    NsPerOp float64 `json:"ns_per_op"`
+    BytesPerOp float64 `json:"bytes_per_op,omitempty"`
+    AllocsPerOp float64 `json:"allocs_per_op,omitempty"`
+}
+
+// Key identifies the benchmark across runs.
+func (r Result) Key() string {
+    return r.Package + "." + r.Name
+}
+
+// trimProcs removes the -GOMAXPROCS suffix of a benchmark name
+func trimProcs(name string) string {
+    if i := strings.LastIndexByte(name, '-'); i >= 0 {
+        if _, err := strconv.Atoi(name[i + 1:]); err == nil {
+            return name[:i]
+        }
+    }
+    return name
+}
+
+// ParseOutput reads the results out of the output of go test -bench.
+func ParseOutput(r io.Reader) ([]Result, error) {
+    scanner := bufio.NewScanner(r)
+    pkg := ""
+    results := []Result{}
+    counts := map[string]int{}
+    index := map[string]int{}
+    for scanner.Scan() {
+        line := scanner.Text()
+        if strings.HasPrefix(line, "pkg: ") {
+            pkg = strings.TrimPrefix(line, "pkg: ")
+            continue
+        }
+        fields := strings.Fields(line)
+        if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+            continue
+        }
+        if _, err := strconv.Atoi(fields[1]); err != nil {
+            continue
+        }
+        result := Result{Package: pkg, Name: trimProcs(fields[0])}
+        // the measurements come as pairs of value and unit
+        for i := 2; i + 1 < len(fields); i += 2 {
+            value, err := strconv.ParseFloat(fields[i], 64)
+            if err != nil {
+                return nil, fmt.Errorf("%s: invalid value '%s'", fields[0], fields[i])
+            }
+            switch fields[i + 1] {
+            case "ns/op":
+                result.NsPerOp = value
+            case "B/op":
+                result.BytesPerOp = value
+            case "allocs/op":
+                result.AllocsPerOp = value
+            }
+        }
+        key := result.Key()
+        i, ok := index[key]
+        if !ok {
+            index[key] = len(results)
+            counts[key] = 1
+            results = append(results, result)
+            continue
+        }
+        // running mean over -count runs
+        counts[key]++
+        n := float64(counts[key])
+        results[i].NsPerOp += (result.NsPerOp - results[i].NsPerOp) / n
+        results[i].BytesPerOp += (result.BytesPerOp - results[i].BytesPerOp) / n
+        results[i].AllocsPerOp += (result.AllocsPerOp - results[i].AllocsPerOp) / n
+    }
+    return results, scanner.Err()
+}
+
+// Record is a Result in the history.
+type Record struct {
+    Commit string `json:"commit"`
+    Time time.Time `json:"time"`
+    Result
+}
+
+// Append adds the results of a run at commit to the history at path.
+func Append(path string, commit string, at time.Time, results []Result) error {
+    if dir := filepath.Dir(path); dir != "." {
+        if err := os.MkdirAll(dir, 0o755); err != nil {
+            return err
+        }
+    }
+    var b bytes.Buffer
+    encoder := json.NewEncoder(&b)
+    for _, result := range results {
+        if err := encoder.Encode(Record{commit, at.UTC(), result}); err != nil {
+            return err
+        }
+    }
+    f, err := os.OpenFile(path, os.O_APPEND | os.O_CREATE | os.O_WRONLY, 0o644)
+    if err != nil {
+        return err
+    }
+    if _, err := f.Write(b.Bytes()); err != nil {
+        f.Close()
+        return err
+    }
+    return f.Close()
+}
+
+// Load reads the history at path, which may not exist yet.
+func Load(path string) ([]Record, error) {
+    data, err := os.ReadFile(path)
+    if errors.Is(err, fs.ErrNotExist) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    records := []Record{}
+    for i, line := range bytes.Split(data, []byte("\n")) {
+        if len(bytes.TrimSpace(line)) == 0 {
+            continue
+        }
+        record := Record{}
+        if err := json.Unmarshal(line, &record); err != nil {
+            return nil, fmt.Errorf("%s, line %d: %w", path, i + 1, err)
+        }
+        records = append(records, record)
+    }
+    return records, nil
+}
+
+// LastRun returns the records of the latest run in the history.
+func LastRun(records []Record) []Record {
+    last := []Record{}
+    for _, record := range records {
+        if len(last) > 0 && record.Time.Before(last[0].Time) {
+            continue
+        }
+        if len(last) > 0 && record.Time.After(last[0].Time) {
+            last = last[:0]
+        }
+        last = append(last, record)
+    }
+    return last
+}
+
+// Change is the difference of a benchmark between two runs.
+type Change struct {
+    Key string
+    Before float64
+    After float64
+}
+
+// Delta returns the relative change in ns/op, 0.1 being 10% slower.
+func (c Change) Delta() float64 {
+    return (c.After - c.Before) / c.Before
+}
+
+func (c Change) String() string {
+    return fmt.Sprintf("%s: %s -> %s (%+.1f%%)", c.Key,
+        time.Duration(c.Before), time.Duration(c.After), c.Delta() * 100)
+}
+
+// Regressions returns the benchmarks that got slower by more than
+// threshold, 0.1 being 10%, sorted by the size of the regression.
+func Regressions(previous []Record, current []Result, threshold float64) []Change {
+    before := map[string]float64{}
+    for _, record := range previous {
+        before[record.Key()] = record.NsPerOp
+    }
+    changes := []Change{}
+    for _, result := range current {
+        b, ok := before[result.Key()]
+        if !ok || b <= 0 {
+            continue
+        }
+        change := Change{result.Key(), b, result.NsPerOp}
+        if change.Delta() > threshold {
+            changes = append(changes, change)
+        }
+    }
+    sort.Slice(changes, func(i int, j int) bool {
+        return changes[i].Delta() > changes[j].Delta()
+    })
+    return changes
+}
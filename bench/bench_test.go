@@ -0,0 +1,71 @@
+package bench
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: stefanvonderkrone/adventOfCode2023/days/day12
+cpu: AMD EPYC
+BenchmarkParse-8   	  229984	      5188 ns/op	    1344 B/op	      21 allocs/op
+BenchmarkSolve-8   	    1171	   1000000 ns/op	  654321 B/op	    4321 allocs/op
+BenchmarkSolve-8   	    1171	   2000000 ns/op	  654321 B/op	    4321 allocs/op
+PASS
+ok  	stefanvonderkrone/adventOfCode2023/days/day12	3.109s
+pkg: stefanvonderkrone/adventOfCode2023/days/day14
+BenchmarkSolve/part2 	     100	     81152 ns/op
+PASS
+`
+
+func TestParseOutput(t *testing.T) {
+    results, err := ParseOutput(strings.NewReader(output))
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := []Result{
+        {"stefanvonderkrone/adventOfCode2023/days/day12", "BenchmarkParse", 5188, 1344, 21},
+        {"stefanvonderkrone/adventOfCode2023/days/day12", "BenchmarkSolve", 1500000, 654321, 4321},
+        {"stefanvonderkrone/adventOfCode2023/days/day14", "BenchmarkSolve/part2", 81152, 0, 0},
+    }
+    if len(results) != len(want) {
+        t.Fatalf("ParseOutput = %+v", results)
+    }
+    for i := range want {
+        if results[i] != want[i] {
+            t.Errorf("result %d = %+v, want %+v", i, results[i], want[i])
+        }
+    }
+}
+
+func TestHistory(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "bench", "history.jsonl")
+    if records, err := Load(path); err != nil || len(records) != 0 {
+        t.Fatalf("Load of a missing history = %v, %v", records, err)
+    }
+    first := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+    old := []Result{{"p", "BenchmarkA", 100, 0, 0}, {"p", "BenchmarkB", 100, 0, 0}}
+    current := []Result{{"p", "BenchmarkA", 105, 0, 0}, {"p", "BenchmarkB", 150, 0, 0}, {"p", "BenchmarkC", 1, 0, 0}}
+    if err := Append(path, "abc1234", first, old); err != nil {
+        t.Fatal(err)
+    }
+    if err := Append(path, "def5678", first.Add(time.Hour), current); err != nil {
+        t.Fatal(err)
+    }
+    records, err := Load(path)
+    if err != nil || len(records) != 5 {
+        t.Fatalf("Load = %+v, %v", records, err)
+    }
+    last := LastRun(records)
+    if len(last) != 3 || last[0].Commit != "def5678" {
+        t.Errorf("LastRun = %+v", last)
+    }
+
+    regressions := Regressions(records[:2], current, 0.1)
+    if len(regressions) != 1 || regressions[0].Key != "p.BenchmarkB" || regressions[0].Delta() != 0.5 {
+        t.Errorf("Regressions = %+v", regressions)
+    }
+}
@@ -0,0 +1,228 @@
+// Package client talks to the Advent of Code website: it downloads puzzle
+// inputs into the input store and submits answers.
+//
+// The site asks to go easy on it, so the client caches every input,
+// waits MinInterval between requests and refuses to submit while the
+// site has asked to wait.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/input"
+)
+
+const (
+    BASE_URL = "https://adventofcode.com"
+    USER_AGENT = "github.com/stefanvonderkrone/adventOfCode2023"
+    MIN_INTERVAL = 3 * time.Second
+)
+
+var ErrNoSession = errors.New("no session cookie, set AOC_SESSION")
+
+type Client struct {
+    BaseURL string
+    Session string
+    Year int
+    UserAgent string
+    // MinInterval is the least time between two requests
+    MinInterval time.Duration
+    HTTP *http.Client
+    Store *input.Store
+
+    mu sync.Mutex
+    last time.Time
+    notBefore time.Time
+}
+
+func New(session string, store *input.Store) *Client {
+    return &Client{
+        BaseURL: BASE_URL,
+        Session: session,
+        Year: store.Year,
+        UserAgent: USER_AGENT,
+        MinInterval: MIN_INTERVAL,
+        HTTP: http.DefaultClient,
+        Store: store,
+    }
+}
+
+// throttle blocks until MinInterval has passed since the last request
+func (c *Client) throttle(ctx context.Context) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if wait := time.Until(c.last.Add(c.MinInterval)); wait > 0 {
+        timer := time.NewTimer(wait)
+        defer timer.Stop()
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        case <-timer.C:
+        }
+    }
+    c.last = time.Now()
+    return nil
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body io.Reader) (string, error) {
+    if c.Session == "" {
+        return "", ErrNoSession
+    }
+    if err := c.throttle(ctx); err != nil {
+        return "", err
+    }
+    req, err := http.NewRequestWithContext(ctx, method, c.BaseURL + path, body)
+    if err != nil {
+        return "", err
+    }
+    req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+    req.Header.Set("User-Agent", c.UserAgent)
+    if body != nil {
+        req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    }
+    resp, err := c.HTTP.Do(req)
+    if err != nil {
+        return "", err
+    }
+    defer resp.Body.Close()
+    data, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return "", err
+    }
+    if resp.StatusCode != http.StatusOK {
+        return "", fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+    }
+    return string(data), nil
+}
+
+// Fetch returns the puzzle input of day, downloading it into the store
+// if it is not there yet.
+func (c *Client) Fetch(ctx context.Context, day int) ([]byte, error) {
+    data, err := os.ReadFile(c.Store.Path(day))
+    if err == nil {
+        return data, nil
+    }
+    if !errors.Is(err, os.ErrNotExist) {
+        return nil, err
+    }
+    body, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", c.Year, day), nil)
+    if err != nil {
+        return nil, err
+    }
+    if err := c.Store.Save(day, []byte(body)); err != nil {
+        return nil, err
+    }
+    return []byte(body), nil
+}
+
+type Verdict int
+
+const (
+    UNKNOWN Verdict = iota
+    CORRECT
+    TOO_HIGH
+    TOO_LOW
+    WRONG
+    // TOO_SOON means an answer was given too recently, see Response.Wait
+    TOO_SOON
+    // ALREADY_SOLVED means the part was completed before
+    ALREADY_SOLVED
+)
+
+var verdictNames = []string{"unknown", "correct", "too high", "too low", "wrong", "too soon", "already solved"}
+
+func (v Verdict) String() string {
+    if v < 0 || int(v) >= len(verdictNames) {
+        return fmt.Sprintf("Verdict(%d)", int(v))
+    }
+    return verdictNames[v]
+}
+
+// Response is the answer of the site to a submission.
+type Response struct {
+    Verdict Verdict
+    // Wait is how long the site wants us to wait before the next answer
+    Wait time.Duration
+    // Message is the text of the response
+    Message string
+}
+
+var (
+    articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+    tagPattern = regexp.MustCompile(`<[^>]*>`)
+    leftPattern = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+    waitPattern = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// ParseResponse reads the verdict out of the page returned for an answer.
+func ParseResponse(page string) Response {
+    message := page
+    if m := articlePattern.FindStringSubmatch(page); m != nil {
+        message = m[1]
+    }
+    message = strings.Join(strings.Fields(tagPattern.ReplaceAllString(message, "")), " ")
+    response := Response{Message: message}
+    switch {
+    case strings.Contains(message, "That's the right answer"):
+        response.Verdict = CORRECT
+    case strings.Contains(message, "answer too recently"):
+        response.Verdict = TOO_SOON
+    case strings.Contains(message, "solving the right level"):
+        response.Verdict = ALREADY_SOLVED
+    case strings.Contains(message, "too high"):
+        response.Verdict = TOO_HIGH
+    case strings.Contains(message, "too low"):
+        response.Verdict = TOO_LOW
+    case strings.Contains(message, "not the right answer"):
+        response.Verdict = WRONG
+    }
+    if m := leftPattern.FindStringSubmatch(message); m != nil {
+        minutes, _ := strconv.Atoi(m[1])
+        seconds, _ := strconv.Atoi(m[2])
+        response.Wait = time.Duration(minutes) * time.Minute + time.Duration(seconds) * time.Second
+    } else if m := waitPattern.FindStringSubmatch(message); m != nil {
+        minutes := 1
+        if m[1] != "one" {
+            minutes, _ = strconv.Atoi(m[1])
+        }
+        response.Wait = time.Duration(minutes) * time.Minute
+    }
+    return response
+}
+
+// Submit sends answer for part of day. While the site has asked to wait
+// it returns TOO_SOON without asking it again.
+func (c *Client) Submit(ctx context.Context, day int, part int, answer int) (Response, error) {
+    if part != 1 && part != 2 {
+        return Response{}, fmt.Errorf("invalid part %d", part)
+    }
+    c.mu.Lock()
+    wait := time.Until(c.notBefore)
+    c.mu.Unlock()
+    if wait > 0 {
+        return Response{Verdict: TOO_SOON, Wait: wait, Message: "waiting for the site to accept answers again"}, nil
+    }
+    form := url.Values{"level": {strconv.Itoa(part)}, "answer": {strconv.Itoa(answer)}}
+    page, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", c.Year, day), strings.NewReader(form.Encode()))
+    if err != nil {
+        return Response{}, err
+    }
+    response := ParseResponse(page)
+    if response.Wait > 0 {
+        c.mu.Lock()
+        c.notBefore = time.Now().Add(response.Wait)
+        c.mu.Unlock()
+    }
+    return response, nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/client/fake"
+	"stefanvonderkrone/adventOfCode2023/input"
+)
+
+func newClient(t *testing.T) (*Client, *fake.Server) {
+    server := fake.NewServer("secret", input.YEAR)
+    t.Cleanup(server.Close)
+    c := New("secret", input.NewStore(t.TempDir()))
+    c.BaseURL = server.URL
+    c.MinInterval = 0
+    return c, server
+}
+
+func TestFetch(t *testing.T) {
+    c, server := newClient(t)
+    server.Inputs[8] = "LLR\n\nAAA = (BBB, BBB)\n"
+    ctx := context.Background()
+    for i := 0; i < 2; i++ {
+        data, err := c.Fetch(ctx, 8)
+        if err != nil || string(data) != server.Inputs[8] {
+            t.Fatalf("Fetch(8) = %q, %v", data, err)
+        }
+    }
+    if n := server.Requests["/2023/day/8/input"]; n != 1 {
+        t.Errorf("input was requested %d times, not cached", n)
+    }
+    if data, err := os.ReadFile(c.Store.Path(8)); err != nil || string(data) != server.Inputs[8] {
+        t.Errorf("input not stored: %q, %v", data, err)
+    }
+    if _, err := c.Fetch(ctx, 9); err == nil {
+        t.Errorf("Fetch of a missing day did not fail")
+    }
+
+    c.Session = "wrong"
+    if _, err := c.Fetch(ctx, 10); err == nil {
+        t.Errorf("Fetch with a wrong session did not fail")
+    }
+    c.Session = ""
+    if _, err := c.Fetch(ctx, 10); !errors.Is(err, ErrNoSession) {
+        t.Errorf("Fetch without a session: %v", err)
+    }
+}
+
+func TestSubmit(t *testing.T) {
+    c, server := newClient(t)
+    server.Answers[[2]int{1, 1}] = 142
+    ctx := context.Background()
+
+    response, err := c.Submit(ctx, 1, 1, 200)
+    if err != nil || response.Verdict != TOO_HIGH || response.Wait != time.Minute {
+        t.Fatalf("Submit too high = %+v, %v", response, err)
+    }
+    // the client remembers to wait, the site is not asked again
+    response, err = c.Submit(ctx, 1, 1, 142)
+    if err != nil || response.Verdict != TOO_SOON || server.Requests["/2023/day/1/answer"] != 1 {
+        t.Fatalf("Submit while waiting = %+v, %v", response, err)
+    }
+
+    // a new client does not know, but the site tells it
+    c2, _ := newClient(t)
+    c2.BaseURL = server.URL
+    response, err = c2.Submit(ctx, 1, 1, 142)
+    if err != nil || response.Verdict != TOO_SOON || response.Wait <= 0 {
+        t.Fatalf("Submit too soon = %+v, %v", response, err)
+    }
+
+    c3, server3 := newClient(t)
+    server3.Answers[[2]int{1, 1}] = 142
+    server3.Wait = 0
+    for _, test := range []struct {
+        answer int
+        verdict Verdict
+    }{
+        {100, TOO_LOW},
+        {142, CORRECT},
+        {142, ALREADY_SOLVED},
+    } {
+        response, err = c3.Submit(ctx, 1, 1, test.answer)
+        if err != nil || response.Verdict != test.verdict {
+            t.Errorf("Submit(%d) = %+v, %v, want %s", test.answer, response, err, test.verdict)
+        }
+    }
+}
+
+func TestParseResponse(t *testing.T) {
+    tests := []struct {
+        page string
+        verdict Verdict
+        wait time.Duration
+    }{
+        {"<article><p>That's the right answer!  You are <em>one gold star</em> closer.</p></article>", CORRECT, 0},
+        {"<article><p>That's not the right answer; your answer is too low.  Please wait one minute before trying again.</p></article>", TOO_LOW, time.Minute},
+        {"<article><p>That's not the right answer.  Please wait 5 minutes before trying again.</p></article>", WRONG, 5 * time.Minute},
+        {"<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.</p></article>", TOO_SOON, 65 * time.Second},
+        {"<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>", ALREADY_SOLVED, 0},
+        {"<html>maintenance</html>", UNKNOWN, 0},
+    }
+    for _, test := range tests {
+        if got := ParseResponse(test.page); got.Verdict != test.verdict || got.Wait != test.wait {
+            t.Errorf("ParseResponse(%q) = %+v, want %s, %s", test.page, got, test.verdict, test.wait)
+        }
+    }
+}
+
+func TestThrottle(t *testing.T) {
+    c, server := newClient(t)
+    server.Inputs[1] = "1abc2\n"
+    server.Inputs[2] = "Game 1: 1 red\n"
+    c.MinInterval = 200 * time.Millisecond
+    start := time.Now()
+    for day := 1; day <= 2; day++ {
+        if _, err := c.Fetch(context.Background(), day); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if elapsed := time.Since(start); elapsed < c.MinInterval {
+        t.Errorf("two requests within %s", elapsed)
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if _, err := c.Fetch(ctx, 3); !errors.Is(err, context.Canceled) {
+        t.Errorf("Fetch with a cancelled context: %v", err)
+    }
+}
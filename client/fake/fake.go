@@ -0,0 +1,134 @@
+// Package fake is a stand-in for the Advent of Code website, so the client
+// can be tested and tried out offline.
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Server serves the inputs and checks the answers it was given. Its
+// fields may be changed while it runs by holding Mu.
+type Server struct {
+    *httptest.Server
+    Mu sync.Mutex
+    Session string
+    Year int
+    Inputs map[int]string
+    // Answers holds the correct answers, by day and part
+    Answers map[[2]int]int
+    // Wait is how long to wait after a wrong answer
+    Wait time.Duration
+    // Requests counts the requests per path
+    Requests map[string]int
+
+    solved map[[2]int]bool
+    notBefore time.Time
+}
+
+// NewServer starts a server accepting the session cookie session. Close
+// it when done.
+func NewServer(session string, year int) *Server {
+    s := &Server{
+        Session: session,
+        Year: year,
+        Inputs: map[int]string{},
+        Answers: map[[2]int]int{},
+        Wait: time.Minute,
+        Requests: map[string]int{},
+        solved: map[[2]int]bool{},
+    }
+    s.Server = httptest.NewServer(s.authorize(http.HandlerFunc(s.route)))
+    return s
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+    var year, day int
+    var page string
+    n, _ := fmt.Sscanf(r.URL.Path, "/%d/day/%d/%s", &year, &day, &page)
+    switch {
+    case n != 3 || year != s.Year:
+        http.NotFound(w, r)
+    case page == "input" && r.Method == http.MethodGet:
+        s.input(w, r, day)
+    case page == "answer" && r.Method == http.MethodPost:
+        s.answer(w, r, day)
+    default:
+        http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+    }
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        s.Mu.Lock()
+        s.Requests[r.URL.Path]++
+        session := s.Session
+        s.Mu.Unlock()
+        cookie, err := r.Cookie("session")
+        if err != nil || cookie.Value != session {
+            http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+func (s *Server) input(w http.ResponseWriter, r *http.Request, day int) {
+    s.Mu.Lock()
+    input, ok := s.Inputs[day]
+    s.Mu.Unlock()
+    if !ok {
+        http.NotFound(w, r)
+        return
+    }
+    fmt.Fprint(w, input)
+}
+
+func article(w http.ResponseWriter, text string) {
+    fmt.Fprintf(w, "<!DOCTYPE html>\n<html><body><main>\n<article><p>%s</p></article>\n</main></body></html>\n", text)
+}
+
+func formatWait(wait time.Duration) string {
+    seconds := int(wait.Round(time.Second) / time.Second)
+    if seconds >= 60 {
+        return fmt.Sprintf("%dm %ds", seconds / 60, seconds % 60)
+    }
+    return fmt.Sprintf("%ds", seconds)
+}
+
+func (s *Server) answer(w http.ResponseWriter, r *http.Request, day int) {
+    part, _ := strconv.Atoi(r.FormValue("level"))
+    answer, err := strconv.Atoi(r.FormValue("answer"))
+    s.Mu.Lock()
+    defer s.Mu.Unlock()
+    key := [2]int{day, part}
+    want, ok := s.Answers[key]
+    switch {
+    case !ok || s.solved[key]:
+        article(w, "You don't seem to be solving the right level.  Did you already complete it? <a href=\"/\">[Return to Day]</a>")
+    case time.Now().Before(s.notBefore):
+        article(w, fmt.Sprintf("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %s left to wait. <a href=\"/\">[Return to Day]</a>", formatWait(time.Until(s.notBefore))))
+    case err == nil && answer == want:
+        s.solved[key] = true
+        article(w, "That's the right answer!  You are one gold star closer to restoring snow operations.")
+    default:
+        hint := ""
+        if err == nil && answer > want {
+            hint = "  your answer is too high."
+        } else if err == nil {
+            hint = "  your answer is too low."
+        }
+        wait := ""
+        if minutes := int(s.Wait / time.Minute); minutes == 1 {
+            wait = "  Please wait one minute before trying again."
+        } else if minutes > 1 {
+            wait = fmt.Sprintf("  Please wait %d minutes before trying again.", minutes)
+        }
+        s.notBefore = time.Now().Add(s.Wait)
+        article(w, fmt.Sprintf("That's not the right answer;%s  If you're stuck, make sure you're using the full input data.%s <a href=\"/\">[Return to Day]</a>", hint, wait))
+    }
+}
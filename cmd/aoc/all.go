@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/days"
+	"stefanvonderkrone/adventOfCode2023/input"
+	"stefanvonderkrone/adventOfCode2023/logging"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+	"stefanvonderkrone/adventOfCode2023/report"
+)
+
+const (
+    STATUS_OK = "ok"
+    STATUS_ERROR = "error"
+    STATUS_REGRESSED = "regressed"
+    STATUS_NO_INPUT = "no input"
+    STATUS_TIMEOUT = "timeout"
+    STATUS_INTERRUPTED = "interrupted"
+)
+
+// outcome is what running a single day of run --all ended in. The
+// solution of an interrupted day only holds the parts before Interrupted.Part.
+type outcome struct {
+    Day int
+    Solution *input.Solution
+    Status string
+    Err error
+    Interrupted *puzzle.Interrupted
+    // Recorded tells whether the store held answers for the input before
+    Recorded bool
+}
+
+func (o outcome) failed() bool {
+    return o.Status != STATUS_OK && o.Status != STATUS_NO_INPUT
+}
+
+// solved tells whether part was solved
+func (o outcome) solved(part int) bool {
+    if o.Solution == nil {
+        return false
+    }
+    return o.Interrupted == nil || part < o.Interrupted.Part
+}
+
+func solveDay(ctx context.Context, store *input.Store, levels *logging.Levels, day int, example int, timeout time.Duration) (o outcome) {
+    o.Day = day
+    defer func() {
+        if r := recover(); r != nil {
+            o.Solution = nil
+            o.Status = STATUS_ERROR
+            o.Err = fmt.Errorf("panic: %v", r)
+        }
+    }()
+    path := store.Path(day)
+    if example > 0 {
+        path = store.ExamplePath(day, example)
+    }
+    src, err := store.Load(day, path, 0)
+    if errors.Is(err, fs.ErrNotExist) {
+        o.Status = STATUS_NO_INPUT
+        return o
+    }
+    if err == nil {
+        if timeout > 0 {
+            var cancel context.CancelFunc
+            ctx, cancel = context.WithTimeout(ctx, timeout)
+            defer cancel()
+        }
+        ctx = levels.Context(ctx, os.Stderr, day)
+        o.Solution, err = input.Solve(ctx, src, days.Solvers[day].Solve)
+    }
+    if errors.As(err, &o.Interrupted) {
+        o.Status = STATUS_INTERRUPTED
+        if errors.Is(err, context.DeadlineExceeded) {
+            o.Status = STATUS_TIMEOUT
+        }
+        o.Err = err
+        return o
+    }
+    if err != nil {
+        o.Status = STATUS_ERROR
+        o.Err = err
+        return o
+    }
+    o.Status = STATUS_OK
+    return o
+}
+
+// solveAll solves every registered day on at most jobs goroutines,
+// in the order of days.Numbers
+func solveAll(ctx context.Context, store *input.Store, levels *logging.Levels, example int, jobs int, timeout time.Duration) []outcome {
+    numbers := days.Numbers()
+    outcomes := make([]outcome, len(numbers))
+    work := make(chan int)
+    var wg sync.WaitGroup
+    for w := 0; w < max(jobs, 1); w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range work {
+                outcomes[i] = solveDay(ctx, store, levels, numbers[i], example, timeout)
+            }
+        }()
+    }
+    for i := range numbers {
+        work <- i
+    }
+    close(work)
+    wg.Wait()
+    return outcomes
+}
+
+// checkRegressions compares the answers with the ones recorded for the
+// same inputs, the store is only written afterwards
+func checkRegressions(store *input.Store, outcomes []outcome) error {
+    for i, o := range outcomes {
+        if o.Status != STATUS_OK {
+            continue
+        }
+        answer, ok, err := store.Recorded(o.Day, o.Solution.Source.SHA256)
+        if err != nil {
+            return err
+        }
+        if !ok {
+            continue
+        }
+        outcomes[i].Recorded = true
+        if err := answer.Check(o.Solution.Result); err != nil {
+            outcomes[i].Status = STATUS_REGRESSED
+            outcomes[i].Err = err
+        }
+    }
+    return nil
+}
+
+func printSummary(outcomes []outcome) {
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintln(w, "day\tpart1\tpart2\ttime\tstatus\t")
+    for _, o := range outcomes {
+        columns := []string{fmt.Sprint(o.Day), "-", "-", "-", o.Status}
+        for part := 1; part <= 2; part++ {
+            if o.solved(part) {
+                columns[part] = fmt.Sprint(o.Solution.Result.Part(part))
+            }
+        }
+        if o.Solution != nil {
+            columns[3] = o.Solution.Duration.String()
+        }
+        fmt.Fprintf(w, "%s\t\n", strings.Join(columns, "\t"))
+    }
+    w.Flush()
+}
+
+// runAll implements run --all. It fails if any day fails, regresses or
+// times out, days without input are skipped.
+func runAll(ctx context.Context, inputFlags *input.Flags, format report.Format, levels *logging.Levels, jobs int, timeout time.Duration) error {
+    if inputFlags.Path != "" {
+        return errors.New("--input cannot be combined with --all")
+    }
+    store := inputFlags.Store()
+    outcomes := solveAll(ctx, store, levels, inputFlags.Example, jobs, timeout)
+    if err := checkRegressions(store, outcomes); err != nil {
+        return err
+    }
+
+    failed := 0
+    for _, o := range outcomes {
+        if o.failed() {
+            failed++
+            fmt.Fprintf(os.Stderr, "day %d: %s\n", o.Day, o.Err)
+            continue
+        }
+        if o.Status == STATUS_OK && !o.Recorded && !inputFlags.NoRecord {
+            if err := store.Record(o.Solution.Source, o.Solution.Result); err != nil {
+                return err
+            }
+        }
+    }
+
+    if format == report.JSON {
+        for _, o := range outcomes {
+            parts := []int{}
+            for part := 1; part <= 2; part++ {
+                if o.solved(part) {
+                    parts = append(parts, part)
+                }
+            }
+            if len(parts) == 0 {
+                continue
+            }
+            if err := report.WriteJSON(os.Stdout, report.Entries(o.Solution, parts...)); err != nil {
+                return err
+            }
+        }
+    } else {
+        printSummary(outcomes)
+    }
+    if failed > 0 {
+        return fmt.Errorf("%d of %d days failed", failed, len(outcomes))
+    }
+    return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/bench"
+)
+
+// gitCommit returns the short hash of HEAD, marked dirty if tracked
+// files were changed
+func gitCommit() (string, error) {
+    out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+    if err != nil {
+        return "", fmt.Errorf("git rev-parse: %w", err)
+    }
+    commit := strings.TrimSpace(string(out))
+    status, err := exec.Command("git", "status", "--porcelain", "--untracked-files=no").Output()
+    if err != nil {
+        return "", fmt.Errorf("git status: %w", err)
+    }
+    if len(bytes.TrimSpace(status)) > 0 {
+        commit += "-dirty"
+    }
+    return commit, nil
+}
+
+func benchmark(args []string) error {
+    flags := flag.NewFlagSet("bench", flag.ExitOnError)
+    flags.Usage = usage
+    pattern := flags.String("bench", ".", "benchmarks to run, as for go test -bench")
+    benchtime := flags.String("benchtime", "", "time or iterations per benchmark, as for go test -benchtime")
+    count := flags.Int("count", 1, "runs per benchmark, the results are averaged")
+    history := flags.String("history", ".bench/history.jsonl", "history of the results")
+    threshold := flags.Float64("threshold", 10, "slow down in percent reported as a regression")
+    packages, err := parseFlags(flags, args)
+    if err != nil {
+        return err
+    }
+    if len(packages) == 0 {
+        packages = []string{"./days/..."}
+    }
+
+    commit, err := gitCommit()
+    if err != nil {
+        return err
+    }
+    records, err := bench.Load(*history)
+    if err != nil {
+        return err
+    }
+    previous := bench.LastRun(records)
+
+    goArgs := []string{"test", "-run", "^$", "-bench", *pattern, "-benchmem", "-count", fmt.Sprint(*count)}
+    if *benchtime != "" {
+        goArgs = append(goArgs, "-benchtime", *benchtime)
+    }
+    var out bytes.Buffer
+    cmd := exec.Command("go", append(goArgs, packages...)...)
+    cmd.Stdout = io.MultiWriter(os.Stdout, &out)
+    cmd.Stderr = os.Stderr
+    if err := cmd.Run(); err != nil {
+        return fmt.Errorf("go test: %w", err)
+    }
+    results, err := bench.ParseOutput(&out)
+    if err != nil {
+        return err
+    }
+    if len(results) == 0 {
+        return fmt.Errorf("no benchmarks matched '%s'", *pattern)
+    }
+    if err := bench.Append(*history, commit, time.Now(), results); err != nil {
+        return err
+    }
+
+    if len(previous) == 0 {
+        fmt.Printf("recorded %d benchmarks of %s, nothing to compare to\n", len(results), commit)
+        return nil
+    }
+    regressions := bench.Regressions(previous, results, *threshold / 100)
+    fmt.Printf("recorded %d benchmarks of %s, compared to %s at %s\n",
+        len(results), commit, previous[0].Commit, previous[0].Time.Local().Format("2006-01-02 15:04"))
+    for _, change := range regressions {
+        fmt.Println(change)
+    }
+    if len(regressions) > 0 {
+        return fmt.Errorf("%d benchmarks slower by more than %g%%", len(regressions), *threshold)
+    }
+    return nil
+}
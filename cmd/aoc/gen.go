@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"stefanvonderkrone/adventOfCode2023/days"
+)
+
+// generate writes a random input of a day to stdout, the same size and
+// seed always give the same input
+func generate(args []string) error {
+    flags := flag.NewFlagSet("gen", flag.ExitOnError)
+    flags.Usage = usage
+    size := flags.Int("size", 100, "lines of the input, rows and columns for grids")
+    seed := flags.Int64("seed", 1, "seed of the random numbers")
+    positional, err := parseFlags(flags, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) != 1 {
+        usage()
+        os.Exit(2)
+    }
+    day, _, err := parseDay(positional[0])
+    if err != nil {
+        return err
+    }
+    if *size < 1 {
+        return fmt.Errorf("invalid size %d", *size)
+    }
+    generator, ok := days.Generators[day]
+    if !ok {
+        return fmt.Errorf("no generator for day %d", day)
+    }
+    _, err = os.Stdout.Write(generator(rand.New(rand.NewSource(*seed)), *size))
+    return err
+}
@@ -0,0 +1,294 @@
+// Command aoc runs the solvers of every implemented day.
+//
+//	aoc run <day> [--part 1|2] [--input file|-] [--example n] [--inputs dir] [--no-record] [--format text|json]
+//	        [--log levels] [--timeout d] [--cpuprofile] [--memprofile] [--trace] [--profile-dir dir]
+//	aoc run --all [--jobs n] [--timeout d] [--example n] [--inputs dir] [--no-record] [--format text|json] [--log levels]
+//	aoc fetch <day> [--inputs dir] [--session-file file]
+//	aoc bench [--bench regexp] [--benchtime d] [--count n] [--history file] [--threshold percent] [packages]
+//	aoc submit <day> <part> [answer] [--inputs dir] [--session-file file]
+//	aoc gen <day> [--size n] [--seed s]
+//
+// Without --input the puzzle input is read from the input store, see
+// package input, and the answers are recorded there. run --all solves
+// every day with an input in the store concurrently, prints a summary
+// and fails if a day fails or its answers differ from the recorded
+// ones. --timeout stops a day that takes too long and reports the parts
+// solved before. --log sets the level of the solvers' log events on
+// stderr, for all days or per day as in --log info,8=debug. The
+// profiling flags write runtime/pprof and runtime/trace files of the
+// solve call, named by day and part. The heap profile of --memprofile
+// is taken right after the call, its allocation counts cover the whole
+// process. fetch and submit talk to the Advent of Code website with the
+// session cookie in AOC_SESSION or the file given by --session-file.
+// submit without an answer solves the day first. bench runs the
+// benchmarks of the days, adds the results to a history keyed by git
+// commit and fails if any got slower than in the previous run by more
+// than the threshold. gen writes a random valid input of about size
+// lines to stdout, to stress test a solver with e.g.
+// aoc gen 12 --size 10000 | aoc run 12 --input -.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+
+	"stefanvonderkrone/adventOfCode2023/client"
+	"stefanvonderkrone/adventOfCode2023/days"
+	"stefanvonderkrone/adventOfCode2023/input"
+	"stefanvonderkrone/adventOfCode2023/logging"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+	"stefanvonderkrone/adventOfCode2023/report"
+)
+
+func usage() {
+    fmt.Fprint(os.Stderr, `usage: aoc run <day> [--part 1|2] [--input file|-] [--example n] [--inputs dir] [--no-record] [--format text|json]
+               [--log levels] [--timeout d] [--cpuprofile] [--memprofile] [--trace] [--profile-dir dir]
+       aoc run --all [--jobs n] [--timeout d] [--example n] [--inputs dir] [--no-record] [--format text|json] [--log levels]
+       aoc fetch <day> [--inputs dir] [--session-file file]
+       aoc submit <day> <part> [answer] [--inputs dir] [--session-file file]
+       aoc bench [--bench regexp] [--benchtime d] [--count n] [--history file] [--threshold percent] [packages]
+       aoc gen <day> [--size n] [--seed s]
+
+days:`)
+    for _, day := range days.Numbers() {
+        fmt.Fprintf(os.Stderr, " %d", day)
+    }
+    fmt.Fprint(os.Stderr, "\n")
+}
+
+// parseFlags allows flags to appear before and after the positional arguments
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+    positional := []string{}
+    for {
+        if err := flags.Parse(args); err != nil {
+            return nil, err
+        }
+        args = flags.Args()
+        if len(args) == 0 {
+            return positional, nil
+        }
+        positional = append(positional, args[0])
+        args = args[1:]
+    }
+}
+
+func parseDay(arg string) (int, puzzle.Solver, error) {
+    day, err := strconv.Atoi(arg)
+    if err != nil {
+        return 0, nil, fmt.Errorf("invalid day '%s'", arg)
+    }
+    solver, ok := days.Solvers[day]
+    if !ok {
+        return 0, nil, fmt.Errorf("no solver for day %d", day)
+    }
+    return day, solver, nil
+}
+
+func run(ctx context.Context, args []string) error {
+    flags := flag.NewFlagSet("run", flag.ExitOnError)
+    flags.Usage = usage
+    part := flags.Int("part", 0, "part to solve, both parts if omitted")
+    inputFlags := input.RegisterFlags(flags)
+    format := report.RegisterFlag(flags)
+    levels := logging.RegisterFlag(flags)
+    profile := registerProfileFlags(flags)
+    all := flags.Bool("all", false, "run every day")
+    jobs := flags.Int("jobs", runtime.NumCPU(), "days to run at once with --all")
+    timeout := flags.Duration("timeout", 0, "stop a day after this long, e.g. 30s")
+    positional, err := parseFlags(flags, args)
+    if err != nil {
+        return err
+    }
+    if *all && len(positional) == 0 {
+        if profile.enabled() {
+            return fmt.Errorf("profiles cannot be taken with --all")
+        }
+        return runAll(ctx, inputFlags, *format, levels, *jobs, *timeout)
+    }
+    if *all || len(positional) != 1 {
+        usage()
+        os.Exit(2)
+    }
+    day, solver, err := parseDay(positional[0])
+    if err != nil {
+        return err
+    }
+    if *part < 0 || *part > 2 {
+        return fmt.Errorf("invalid part %d", *part)
+    }
+    if *timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, *timeout)
+        defer cancel()
+    }
+    parts := []int{1, 2}
+    if *part != 0 {
+        parts = []int{*part}
+    }
+    ctx = levels.Context(ctx, os.Stderr, day)
+    solution, err := inputFlags.Run(ctx, day, profile.wrap(day, *part, solver.Solve))
+    var interrupted *puzzle.Interrupted
+    if errors.As(err, &interrupted) {
+        // report the parts solved before
+        solved := []int{}
+        for _, p := range parts {
+            if p < interrupted.Part {
+                solved = append(solved, p)
+            }
+        }
+        if len(solved) > 0 {
+            if err := printParts(*format, solution, solved); err != nil {
+                return err
+            }
+        }
+    }
+    if err != nil {
+        return fmt.Errorf("day %d: %w", day, err)
+    }
+    return printParts(*format, solution, parts)
+}
+
+// printParts prints the answers to parts of solution in format
+func printParts(format report.Format, solution *input.Solution, parts []int) error {
+    if format == report.JSON {
+        return report.WriteJSON(os.Stdout, report.Entries(solution, parts...))
+    }
+    for _, p := range parts {
+        fmt.Printf("day %d, part %d: %d\n", solution.Source.Day, p, solution.Result.Part(p))
+    }
+    return nil
+}
+
+// clientFlags adds the flags of the commands talking to the website
+func clientFlags(flags *flag.FlagSet) func() (*client.Client, error) {
+    dir := flags.String("inputs", input.DIR, "directory of the input store")
+    sessionFile := flags.String("session-file", "", "file holding the session cookie, AOC_SESSION if omitted")
+    baseURL := flags.String("url", client.BASE_URL, "address of the website")
+    return func() (*client.Client, error) {
+        session := os.Getenv("AOC_SESSION")
+        if *sessionFile != "" {
+            data, err := os.ReadFile(*sessionFile)
+            if err != nil {
+                return nil, err
+            }
+            session = string(bytes.TrimSpace(data))
+        }
+        c := client.New(session, input.NewStore(*dir))
+        c.BaseURL = *baseURL
+        return c, nil
+    }
+}
+
+func fetch(ctx context.Context, args []string) error {
+    flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+    flags.Usage = usage
+    newClient := clientFlags(flags)
+    positional, err := parseFlags(flags, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) != 1 {
+        usage()
+        os.Exit(2)
+    }
+    day, err := strconv.Atoi(positional[0])
+    if err != nil || day < 1 || day > 25 {
+        return fmt.Errorf("invalid day '%s'", positional[0])
+    }
+    c, err := newClient()
+    if err != nil {
+        return err
+    }
+    if _, err := c.Fetch(ctx, day); err != nil {
+        return fmt.Errorf("day %d: %w", day, err)
+    }
+    fmt.Println(c.Store.Path(day))
+    return nil
+}
+
+func submit(ctx context.Context, args []string) error {
+    flags := flag.NewFlagSet("submit", flag.ExitOnError)
+    flags.Usage = usage
+    newClient := clientFlags(flags)
+    positional, err := parseFlags(flags, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) != 2 && len(positional) != 3 {
+        usage()
+        os.Exit(2)
+    }
+    day, solver, err := parseDay(positional[0])
+    if err != nil {
+        return err
+    }
+    part, err := strconv.Atoi(positional[1])
+    if err != nil || part < 1 || part > 2 {
+        return fmt.Errorf("invalid part '%s'", positional[1])
+    }
+    c, err := newClient()
+    if err != nil {
+        return err
+    }
+    var answer int
+    if len(positional) == 3 {
+        answer, err = strconv.Atoi(positional[2])
+        if err != nil {
+            return fmt.Errorf("invalid answer '%s'", positional[2])
+        }
+    } else {
+        inputFlags := input.Flags{Dir: c.Store.Dir}
+        solution, err := inputFlags.Run(ctx, day, solver.Solve)
+        if err != nil {
+            return fmt.Errorf("day %d: %w", day, err)
+        }
+        answer = solution.Result.Part(part)
+    }
+    response, err := c.Submit(ctx, day, part, answer)
+    if err != nil {
+        return fmt.Errorf("day %d: %w", day, err)
+    }
+    fmt.Printf("day %d, part %d: %d is %s\n%s\n", day, part, answer, response.Verdict, response.Message)
+    if response.Verdict != client.CORRECT && response.Verdict != client.ALREADY_SOLVED {
+        return fmt.Errorf("day %d, part %d: answer %d is %s", day, part, answer, response.Verdict)
+    }
+    return nil
+}
+
+func main() {
+    if len(os.Args) < 2 {
+        usage()
+        os.Exit(2)
+    }
+    // stop solving cleanly on ^C
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+    defer stop()
+    var err error
+    switch os.Args[1] {
+    case "run":
+        err = run(ctx, os.Args[2:])
+    case "fetch":
+        err = fetch(ctx, os.Args[2:])
+    case "submit":
+        err = submit(ctx, os.Args[2:])
+    case "bench":
+        err = benchmark(os.Args[2:])
+    case "gen":
+        err = generate(os.Args[2:])
+    default:
+        usage()
+        os.Exit(2)
+    }
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "aoc: %s\n", err)
+        stop()
+        os.Exit(1)
+    }
+}
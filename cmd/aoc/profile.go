@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+// profileFlags select the profiles taken of a solve call
+type profileFlags struct {
+    Dir string
+    CPU bool
+    Mem bool
+    Trace bool
+}
+
+func registerProfileFlags(flags *flag.FlagSet) *profileFlags {
+    f := &profileFlags{}
+    flags.StringVar(&f.Dir, "profile-dir", ".", "directory of the profiles")
+    flags.BoolVar(&f.CPU, "cpuprofile", false, "write a CPU profile to day<NN>[_part<P>].cpu.pprof")
+    flags.BoolVar(&f.Mem, "memprofile", false, "write a heap profile taken right after solving to day<NN>[_part<P>].mem.pprof, its allocation counts cover the whole process")
+    flags.BoolVar(&f.Trace, "trace", false, "write an execution trace to day<NN>[_part<P>].trace")
+    return f
+}
+
+func (f *profileFlags) enabled() bool {
+    return f.CPU || f.Mem || f.Trace
+}
+
+// path returns the name of a profile, part 0 meaning both parts
+func (f *profileFlags) path(day int, part int, ext string) string {
+    name := fmt.Sprintf("day%02d", day)
+    if part != 0 {
+        name += fmt.Sprintf("_part%d", part)
+    }
+    return filepath.Join(f.Dir, name + ext)
+}
+
+func (f *profileFlags) create(day int, part int, ext string) (*os.File, error) {
+    if err := os.MkdirAll(f.Dir, 0o755); err != nil {
+        return nil, err
+    }
+    file, err := os.Create(f.path(day, part, ext))
+    if err != nil {
+        return nil, err
+    }
+    fmt.Fprintf(os.Stderr, "writing %s\n", file.Name())
+    return file, nil
+}
+
+// wrap returns solve, profiled as selected. Both parts are solved by the
+// same call, so selecting a part only changes the names of the files.
+func (f *profileFlags) wrap(day int, part int, solve puzzle.SolverFunc) puzzle.SolverFunc {
+    if !f.enabled() {
+        return solve
+    }
+    return func(ctx context.Context, r io.Reader) (result puzzle.Result, err error) {
+        closeFile := func(file *os.File) {
+            if cerr := file.Close(); cerr != nil && err == nil {
+                err = cerr
+            }
+        }
+        if f.CPU {
+            file, err := f.create(day, part, ".cpu.pprof")
+            if err != nil {
+                return puzzle.Result{}, err
+            }
+            defer closeFile(file)
+            if err := pprof.StartCPUProfile(file); err != nil {
+                return puzzle.Result{}, err
+            }
+            defer pprof.StopCPUProfile()
+        }
+        if f.Trace {
+            file, err := f.create(day, part, ".trace")
+            if err != nil {
+                return puzzle.Result{}, err
+            }
+            defer closeFile(file)
+            if err := trace.Start(file); err != nil {
+                return puzzle.Result{}, err
+            }
+            defer trace.Stop()
+        }
+        result, err = solve(ctx, r)
+        if f.Mem {
+            if merr := f.writeHeap(day, part); merr != nil && err == nil {
+                err = merr
+            }
+        }
+        return result, err
+    }
+}
+
+// writeHeap writes a heap profile of what is still in use right after
+// the solve call. Its allocation counts go back to the start of the
+// process, of which loading the input is the main part.
+func (f *profileFlags) writeHeap(day int, part int) error {
+    file, err := f.create(day, part, ".mem.pprof")
+    if err != nil {
+        return err
+    }
+    runtime.GC()
+    if err := pprof.Lookup("heap").WriteTo(file, 0); err != nil {
+        file.Close()
+        return err
+    }
+    return file.Close()
+}
@@ -1,97 +1,88 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"flag"
 	"fmt"
 	"os"
-)
-
-var numbers = map[rune]int{
-    '1': 1,
-    '2': 2,
-    '3': 3,
-    '4': 4,
-    '5': 5,
-    '6': 6,
-    '7': 7,
-    '8': 8,
-    '9': 9,
-}
 
-var numberNames = map[string]int{
-    "one": 1,
-    "two": 2,
-    "three": 3,
-    "four": 4,
-    "five": 5,
-    "six": 6,
-    "seven": 7,
-    "eight": 8,
-    "nine": 9,
-}
+	"stefanvonderkrone/adventOfCode2023/days/day01"
+	"stefanvonderkrone/adventOfCode2023/input"
+	"stefanvonderkrone/adventOfCode2023/logging"
+	"stefanvonderkrone/adventOfCode2023/report"
+)
 
-func readNameAt(line string, index int) (int, bool) {
-    chars := []rune(line)
-    numChars := len(chars)
-    for name, num := range numberNames {
-        nameLength := len([]rune(name))
-        endIndex := index + nameLength
-        if endIndex > numChars {
-            endIndex = numChars
-        }
-        n := string(chars[index:endIndex])
-        if n != name {
-            continue
-        }
-        if _, ok := numberNames[n]; ok {
-            return num, true
-        }
+func main() {
+    flags := input.RegisterFlags(flag.CommandLine)
+    format := report.RegisterFlag(flag.CommandLine)
+    levels := logging.RegisterFlag(flag.CommandLine)
+    words := flag.String("words", "en", "number words for part 2, en, de, fr, numerals or a file of '<word> <value>' lines")
+    compound := flag.Bool("compound", false, "read digit runs and compound numerals for part 2, with the numerals words unless -words is given")
+    combineName := flag.String("combine", "tens", "how to join the first and last number of a line, tens, concat or sum")
+    explain := flag.String("explain", "", "instead of solving, explain each line read in this mode, digits, digits+words or compound")
+    flag.Parse()
+    combine, ok := day01.Combines[*combineName]
+    if !ok {
+        fmt.Fprintf(os.Stderr, "unknown rule '%s'\n", *combineName)
+        os.Exit(2)
     }
-    return -1, false
-}
-
-func readFirstNum(line string) int {
-    for index, char := range line {
-        if num, ok := numbers[char]; ok {
-            return num
+    // other answers than the puzzle's are not recorded
+    if *words != "en" || *compound || *combineName != "tens" {
+        flags.NoRecord = true
+    }
+    part2 := day01.DIGITS_AND_WORDS
+    if *compound {
+        part2 = day01.COMPOUND
+    }
+    compoundWords := *compound
+    var explainMode day01.Mode
+    if *explain != "" {
+        var err error
+        explainMode, err = day01.ParseMode(*explain)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
         }
-        if num, ok := readNameAt(line, index); ok {
-            return num
+        compoundWords = compoundWords || explainMode == day01.COMPOUND
+    }
+    if compoundWords {
+        wordsSet := false
+        flag.Visit(func(f *flag.Flag) {
+            wordsSet = wordsSet || f.Name == "words"
+        })
+        if !wordsSet {
+            *words = "numerals"
         }
     }
-    return 0
-}
-
-func readLastNum(line string) int {
-    chars := []rune(line)
-    length := len(chars) - 1
-    for i := length; i >= 0; i-- {
-        char := chars[i]
-        if num, ok := numbers[char]; ok {
-            return num
+    table, err := day01.OpenTable(*words)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+    if *explain != "" {
+        src, err := flags.Store().Load(1, flags.Path, flags.Example)
+        if err == nil {
+            _, err = table.Explain(os.Stdout, src.Reader(), explainMode, combine)
         }
-        if num, ok := readNameAt(line, i); ok {
-            return num
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(1)
         }
+        return
     }
-    return 0;
-}
-
-func readCalibration(line string) int {
-    firstNum := readFirstNum(line)
-    lastNum := readLastNum(line)
-    return firstNum * 10 + lastNum
-}
-
-func main() {
-	scanner := bufio.NewScanner(os.Stdin)
-
-    sum := 0
-    for scanner.Scan() {
-        line := scanner.Text()
-        calibration := readCalibration(line)
-        sum = sum + calibration
+    solver := table.Solver(part2, combine)
+    solution, err := flags.Run(levels.Context(context.Background(), os.Stderr, 1), 1, solver.Solve)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
     }
-    fmt.Printf("%d\n", sum)
+    if *format == report.JSON {
+        if err := report.WriteJSON(os.Stdout, report.Entries(solution)); err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(1)
+        }
+        return
+    }
+    fmt.Printf("%d\n", solution.Result.Part1)
+    fmt.Printf("%d\n", solution.Result.Part2)
 }
-
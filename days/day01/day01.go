@@ -0,0 +1,179 @@
+package day01
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+// Mode tells which tokens of a line count as numbers
+type Mode int
+
+const (
+    // DIGITS reads only 1 to 9, as in part 1
+    DIGITS Mode = iota
+    // DIGITS_AND_WORDS also reads the spelled out one to nine, as in part 2
+    DIGITS_AND_WORDS
+    // COMPOUND reads runs of digits and compound numerals like
+    // twentythree, see numeral, and the last number is the one ending
+    // last
+    COMPOUND
+)
+
+var modeNames = []string{"digits", "digits+words", "compound"}
+
+func (m Mode) String() string {
+    if m < 0 || int(m) >= len(modeNames) {
+        return fmt.Sprintf("Mode(%d)", int(m))
+    }
+    return modeNames[m]
+}
+
+// ParseMode returns the mode of a name like digits+words
+func ParseMode(name string) (Mode, error) {
+    for m, modeName := range modeNames {
+        if modeName == name {
+            return Mode(m), nil
+        }
+    }
+    return 0, fmt.Errorf("unknown calibration mode '%s'", name)
+}
+
+// readCalibration returns the calibration value of line read with table
+func readCalibration(table *Table, line string, mode Mode, combine Combine) (int, error) {
+    sums, err := table.calibrate(strings.NewReader(line), combine, mode)
+    if err != nil {
+        return 0, err
+    }
+    return sums[mode], nil
+}
+
+// Calibration returns the calibration value of line, 0 if it has no digit
+func Calibration(line string, mode Mode) int {
+    return ENGLISH.Calibration(line, mode)
+}
+
+// Calibrate returns the sum of the calibration values of every line
+func Calibrate(r io.Reader, mode Mode) (int, error) {
+    return ENGLISH.Calibrate(r, mode)
+}
+
+// Calibration returns the calibration value of line read with t, also 0
+// if it has a number of more than MAX_DIGITS digits
+func (t *Table) Calibration(line string, mode Mode) int {
+    n, _ := readCalibration(t, line, mode, TENS)
+    return n
+}
+
+// Calibrate returns the sum of the calibration values of every line
+// read with t
+func (t *Table) Calibrate(r io.Reader, mode Mode) (int, error) {
+    return t.CalibrateWith(r, mode, TENS)
+}
+
+// CalibrateWith is Calibrate with the first and the last number of each
+// line joined by combine
+func (t *Table) CalibrateWith(r io.Reader, mode Mode, combine Combine) (int, error) {
+    sums, err := t.calibrate(r, combine, mode)
+    if err != nil {
+        return 0, err
+    }
+    return sums[mode], nil
+}
+
+// calibrate sums the values of every line in each of modes, indexed by
+// mode, in a single pass over r
+func (t *Table) calibrate(r io.Reader, combine Combine, modes ...Mode) ([]int, error) {
+    sums := make([]int, len(modeNames))
+    err := t.scanLines(r, modes, false, func(line []byte, picks []*pick) {
+        for mode, p := range picks {
+            if p != nil {
+                sums[mode] += p.value(combine)
+            }
+        }
+    })
+    if err != nil {
+        return nil, err
+    }
+    return sums, nil
+}
+
+// scanLines reads the numbers of every line of r in each of modes and
+// calls endLine with the picks indexed by mode, nil for the modes not
+// read. With keep endLine also gets the text of the line and the picks
+// all their tokens.
+func (t *Table) scanLines(r io.Reader, modes []Mode, keep bool, endLine func(line []byte, picks []*pick)) error {
+    picks := make([]*pick, len(modeNames))
+    for _, mode := range modes {
+        if mode < 0 || int(mode) >= len(modeNames) {
+            return fmt.Errorf("unknown calibration mode %s", mode)
+        }
+        picks[mode] = &pick{ends: mode == COMPOUND, keep: keep}
+    }
+    digitsOnly, withWords := picks[DIGITS], picks[DIGITS_AND_WORDS]
+    var compound *numerals
+    if picks[COMPOUND] != nil {
+        compound = newNumerals(t.matcher.longest(), picks[COMPOUND].add)
+    }
+    lineNumber := 1
+    var err error
+    s := &lineScanner{matcher: t.matcher, words: withWords != nil || compound != nil, keep: keep}
+    s.token = func(token Token) {
+        if compound != nil && err == nil {
+            err = puzzle.AtLine(compound.add(token), lineNumber)
+        }
+        // only digit runs start with 0
+        if !token.Word && token.Value == 0 {
+            return
+        }
+        if withWords != nil {
+            withWords.add(token)
+        }
+        if digitsOnly != nil && !token.Word {
+            digitsOnly.add(token)
+        }
+    }
+    s.endLine = func(line []byte) {
+        endLine(line, picks)
+        for _, p := range picks {
+            if p != nil {
+                p.reset()
+            }
+        }
+        if compound != nil {
+            compound.endLine()
+        }
+        lineNumber++
+    }
+    if _, copyErr := io.Copy(s, r); copyErr != nil {
+        return copyErr
+    }
+    s.Close()
+    return err
+}
+
+// Solve reads the digits only for part 1 and the English words too for
+// part 2, in one pass over the document
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    return ENGLISH.Solve(ctx, r)
+}
+
+// Solve solves the puzzle with the words of t
+func (t *Table) Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    return t.Solver(DIGITS_AND_WORDS, TENS).Solve(ctx, r)
+}
+
+// Solver returns a solver reading the digits only for part 1 and part2
+// for part 2, joining the numbers of a line by combine
+func (t *Table) Solver(part2 Mode, combine Combine) puzzle.Solver {
+    return puzzle.SolverFunc(func(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+        sums, err := t.calibrate(r, combine, DIGITS, part2)
+        if err != nil {
+            return puzzle.Result{}, err
+        }
+        return puzzle.Result{Part1: sums[DIGITS], Part2: sums[part2]}, nil
+    })
+}
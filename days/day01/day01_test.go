@@ -0,0 +1,408 @@
+package day01
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"stefanvonderkrone/adventOfCode2023/prop"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day01_part2.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func readLines(input []byte) []string {
+    scanner := bufio.NewScanner(bytes.NewReader(input))
+    lines := []string{}
+    for scanner.Scan() {
+        lines = append(lines, scanner.Text())
+    }
+    return lines
+}
+
+// referenceCalibration looks for the digits and every word of table at
+// each rune index, forwards for the first and backwards for the last
+func referenceCalibration(table *Table, line string, mode Mode) int {
+    chars := []rune(line)
+    numAt := func(index int) (int, bool) {
+        if chars[index] >= '1' && chars[index] <= '9' {
+            return int(chars[index] - '0'), true
+        }
+        if mode != DIGITS_AND_WORDS {
+            return 0, false
+        }
+        // longest first
+        for i, word := range table.words {
+            if index + len(word) <= len(chars) && string(chars[index:index + len(word)]) == string(word) {
+                return table.values[i], true
+            }
+        }
+        return 0, false
+    }
+    first, last := 0, 0
+    for i := range chars {
+        if n, ok := numAt(i); ok {
+            first = n
+            break
+        }
+    }
+    for i := len(chars) - 1; i >= 0; i-- {
+        if n, ok := numAt(i); ok {
+            last = n
+            break
+        }
+    }
+    return first * 10 + last
+}
+
+func TestReferenceCalibration(t *testing.T) {
+    // words sharing prefixes, suffixes and each other
+    overlapping, err := NewTable(map[string]int{"ab": 1, "bab": 2, "abab": 3, "b": 4, "zwölf": 5, "ölf": 6, "ö": 7})
+    if err != nil {
+        t.Fatal(err)
+    }
+    tables := []*Table{ENGLISH, GERMAN, FRENCH, overlapping}
+    prop.Check(t, func(rng *rand.Rand) error {
+        table := tables[rng.Intn(len(tables))]
+        line := []rune{}
+        for i := rng.Intn(20); i > 0; i-- {
+            switch rng.Intn(4) {
+            case 0:
+                line = append(line, table.words[rng.Intn(len(table.words))]...)
+            case 1:
+                line = append(line, rune('0' + rng.Intn(10)))
+            default:
+                line = append(line, []rune("abnoöüzwßeﬁ")[rng.Intn(11)])
+            }
+        }
+        for _, mode := range []Mode{DIGITS, DIGITS_AND_WORDS} {
+            if got, want := table.Calibration(string(line), mode), referenceCalibration(table, string(line), mode); got != want {
+                return fmt.Errorf("Calibration(%q, %s) = %d, want %d", string(line), mode, got, want)
+            }
+        }
+        return nil
+    })
+}
+
+// referenceCompound tries every part of line as a digit run or, split
+// into words in every way, as a numeral. It reports a run of more than
+// MAX_DIGITS digits.
+func referenceCompound(table *Table, line string, combine Combine) (int, bool) {
+    for _, run := range strings.FieldsFunc(line, func(c rune) bool { return c < '0' || c > '9' }) {
+        if len(run) > MAX_DIGITS {
+            return 0, true
+        }
+    }
+    chars := []rune(line)
+    var parse func(rest []rune, n numeral) (int, bool)
+    parse = func(rest []rune, n numeral) (int, bool) {
+        for i, word := range table.words {
+            if len(word) > len(rest) || string(rest[:len(word)]) != string(word) {
+                continue
+            }
+            next, ok := n.step(table.values[i])
+            if !ok {
+                continue
+            }
+            if len(word) == len(rest) {
+                return next.value(), true
+            }
+            if value, ok := parse(rest[len(word):], next); ok {
+                return value, true
+            }
+        }
+        return 0, false
+    }
+    number := func(part []rune) (int, bool) {
+        value := 0
+        for _, c := range part {
+            if c < '0' || c > '9' {
+                return parse(part, numeral{})
+            }
+            value = value * 10 + int(c - '0')
+        }
+        return value, true
+    }
+    first, last := -1, -1
+    for i := 0; i < len(chars) && first < 0; i++ {
+        for j := len(chars); j > i; j-- {
+            if n, ok := number(chars[i:j]); ok {
+                first = n
+                break
+            }
+        }
+    }
+    for j := len(chars); j > 0 && last < 0; j-- {
+        for i := 0; i < j; i++ {
+            if n, ok := number(chars[i:j]); ok {
+                last = n
+                break
+            }
+        }
+    }
+    if first < 0 {
+        return 0, false
+    }
+    return combine(first, last), false
+}
+
+func TestReferenceCompound(t *testing.T) {
+    tables := []*Table{NUMERALS, ENGLISH, GERMAN}
+    prop.Check(t, func(rng *rand.Rand) error {
+        table := tables[rng.Intn(len(tables))]
+        line := []rune{}
+        for i := rng.Intn(12); i > 0; i-- {
+            switch rng.Intn(5) {
+            case 0:
+                line = append(line, rune('0' + rng.Intn(10)))
+            case 1:
+                line = append(line, []rune("xyeüa")[rng.Intn(5)])
+            default:
+                line = append(line, table.words[rng.Intn(len(table.words))]...)
+            }
+        }
+        for name, combine := range Combines {
+            got, err := readCalibration(table, string(line), COMPOUND, combine)
+            if want, wantErr := referenceCompound(table, string(line), combine); got != want || (err != nil) != wantErr {
+                return fmt.Errorf("readCalibration(%q, %s) = %d, %v, want %d", string(line), name, got, err, want)
+            }
+        }
+        return nil
+    })
+}
+
+func TestCompound(t *testing.T) {
+    tests := []struct {
+        line string
+        first int
+        last int
+    }{
+        {"twentythree", 23, 23},
+        {"xonehundredfivex", 105, 105},
+        {"eleven", 11, 11},
+        {"ab12cd3405", 12, 3405},
+        {"0", 0, 0},
+        {"sixty7seventeen", 60, 17},
+        // hundred only follows a unit, the numbers split differently
+        // from each end
+        {"twentythreehundred", 23, 300},
+        {"fivethousandtwohundredthousand", 5200, 200000},
+        {"onemilliontwothousandthirty", 1002030, 1002030},
+        // overlapping words are no numeral
+        {"eightwo", 8, 2},
+        {"fortyeightwo", 48, 2},
+        {"seventeentwenty", 17, 20},
+        {"fiftyfifty", 50, 50},
+        {"hundred", 0, 0},
+    }
+    for _, test := range tests {
+        got, err := readCalibration(NUMERALS, test.line, COMPOUND, func(first int, last int) int {
+            if first != test.first || last != test.last {
+                t.Errorf("%s: first %d, last %d, want %d, %d", test.line, first, last, test.first, test.last)
+            }
+            return first
+        })
+        if err != nil {
+            t.Errorf("%s: %v", test.line, err)
+        }
+        if test.first == 0 && got != 0 {
+            t.Errorf("%s: %d, want 0", test.line, got)
+        }
+    }
+    input := "9\n1234567890\n"
+    if _, err := NUMERALS.CalibrateWith(strings.NewReader(input), COMPOUND, TENS); err == nil || err.Error() != "line 2, column 1: number of more than 9 digits" {
+        t.Errorf("CalibrateWith(%q) error = %v", input, err)
+    }
+}
+
+func TestCombines(t *testing.T) {
+    for name, want := range map[string]int{"tens": 335 + 17, "concat": 23105 + 17, "sum": 128 + 8} {
+        got, err := NUMERALS.CalibrateWith(strings.NewReader("twentythreexonehundredfive\n1seven"), COMPOUND, Combines[name])
+        if err != nil || got != want {
+            t.Errorf("%s: %d, %v, want %d", name, got, err, want)
+        }
+    }
+}
+
+func TestExplain(t *testing.T) {
+    tests := []struct {
+        mode Mode
+        input string
+        want string
+    }{
+        {DIGITS_AND_WORDS, "eightwothree\nabc\n", `line 1: eightwothree
+    at 0: eight = 8, overlaps two
+    at 4: two = 2, overlaps eight
+    at 7: three = 3
+    first eight at 0, last three at 7: 83
+line 2: abc
+    no number: 0
+1 of 2 lines of value 0: 2
+sum: 83
+`},
+        {COMPOUND, "ä12twentyfive", `line 1: ä12twentyfive
+    at 1: 12 = 12
+    at 1: 1 = 1, within 12
+    at 3: twentyfive = 25
+    at 3: twenty = 20, within twentyfive
+    at 9: five = 5, within twentyfive
+    first 12 at 1, last twentyfive at 3: 145
+no line of value 0
+sum: 145
+`},
+    }
+    for _, test := range tests {
+        var b strings.Builder
+        sum, err := NUMERALS.Explain(&b, strings.NewReader(test.input), test.mode, TENS)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if b.String() != test.want {
+            t.Errorf("Explain(%q, %s) wrote\n%s\nwant\n%s", test.input, test.mode, b.String(), test.want)
+        }
+        if want, _ := NUMERALS.CalibrateWith(strings.NewReader(test.input), test.mode, TENS); sum != want {
+            t.Errorf("Explain(%q, %s) = %d, want %d", test.input, test.mode, sum, want)
+        }
+    }
+}
+
+func TestCalibrate(t *testing.T) {
+    input := readExample(t)
+    for mode, want := range map[Mode]int{DIGITS: 209, DIGITS_AND_WORDS: 281} {
+        if got, err := Calibrate(bytes.NewReader(input), mode); err != nil || got != want {
+            t.Errorf("Calibrate(%s) = %d, %v, want %d", mode, got, err, want)
+        }
+    }
+    if _, err := Calibrate(bytes.NewReader(input), Mode(7)); err == nil {
+        t.Errorf("Calibrate(%s) succeeded", Mode(7))
+    }
+    if got := Calibration("eightwothree", DIGITS); got != 0 {
+        t.Errorf("Calibration(eightwothree, %s) = %d, want 0", DIGITS, got)
+    }
+}
+
+func TestTables(t *testing.T) {
+    tests := []struct {
+        table string
+        line string
+        want int
+    }{
+        {"de", "xfünfundzwanzigdreiy", 53},
+        {"de", "ßeinsß", 11},
+        {"fr", "quatrevingtdixneuf", 49},
+        {"fr", "aucune", 11},
+        // overlapping words share letters
+        {"fr", "huitrois", 83},
+        {"en", "fünf9", 99},
+    }
+    for _, test := range tests {
+        if got := Tables[test.table].Calibration(test.line, DIGITS_AND_WORDS); got != test.want {
+            t.Errorf("%s: Calibration(%q) = %d, want %d", test.table, test.line, got, test.want)
+        }
+    }
+}
+
+func TestLoadTable(t *testing.T) {
+    table, err := LoadTable(strings.NewReader("# Zahlen über neun\nzwölf 12\nelf 11\n\nzwei 2\n"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    // zwölf and zwei start alike, the first line has only one of them
+    for line, want := range map[string]int{"äzwölf": 132, "zweielf": 31, "öelfzwö": 121} {
+        if got := table.Calibration(line, DIGITS_AND_WORDS); got != want {
+            t.Errorf("Calibration(%q) = %d, want %d", line, got, want)
+        }
+    }
+    for input, want := range map[string]string{
+        "zwölf 12\nzwölf 13": "line 2, column 1: duplicate word 'zwölf'",
+        "zwölf": "line 1, column 1: expected '<word> <value>', found 'zwölf'",
+        "zwölf x": "line 1, column 7: invalid number 'x': invalid syntax",
+        "f5 5": "word 'f5' contains a digit",
+    } {
+        if _, err := LoadTable(strings.NewReader(input)); err == nil || err.Error() != want {
+            t.Errorf("LoadTable(%q) error = %v, want %s", input, err, want)
+        }
+    }
+}
+
+func TestLongestWord(t *testing.T) {
+    table, err := NewTable(map[string]int{"vier": 4, "vierzehn": 14})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got := table.Calibration("vierzehn", DIGITS_AND_WORDS); got != 154 {
+        t.Errorf("Calibration(vierzehn) = %d, want 154", got)
+    }
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        readLines(input)
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    lines := readLines(readExample(b))
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        for _, line := range lines {
+            readCalibration(ENGLISH, line, DIGITS, TENS)
+            readCalibration(ENGLISH, line, DIGITS_AND_WORDS, TENS)
+        }
+    }
+}
+
+func FuzzReadCalibration(f *testing.F) {
+    for _, line := range strings.Split(string(readExample(f)), "\n") {
+        f.Add(line)
+    }
+    f.Fuzz(func(t *testing.T, line string) {
+        // a line is what the scanner would split off
+        line, _, _ = strings.Cut(line, "\n")
+        for name, table := range Tables {
+            for _, mode := range []Mode{DIGITS, DIGITS_AND_WORDS} {
+                n, err := readCalibration(table, line, mode, TENS)
+                if err != nil {
+                    t.Errorf("readCalibration(%s, %q, %s): %v", name, line, mode, err)
+                }
+                if want := referenceCalibration(table, line, mode); n != want {
+                    t.Errorf("readCalibration(%s, %q, %s) = %d, want %d", name, line, mode, n, want)
+                }
+            }
+            // digit runs join by rune offset, which assumes valid UTF-8,
+            // and the reference is too slow for long lines
+            if !utf8.ValidString(line) || len(line) > 64 {
+                continue
+            }
+            n, err := readCalibration(table, line, COMPOUND, CONCAT)
+            if want, wantErr := referenceCompound(table, line, CONCAT); n != want || (err != nil) != wantErr {
+                t.Errorf("readCalibration(%s, %q, %s) = %d, %v, want %d", name, line, COMPOUND, n, err, want)
+            }
+        }
+    })
+}
+
+func BenchmarkCalibrate(b *testing.B) {
+    document := Generate(rand.New(rand.NewSource(1)), 100000)
+    // and a single line of a megabyte
+    document = append(document, bytes.Repeat([]byte("xtwone3fourzz"), 1 << 20 / 13)...)
+    b.SetBytes(int64(len(document)))
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if _, err := Solve(context.Background(), bytes.NewReader(document)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
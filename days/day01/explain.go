@@ -0,0 +1,93 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Explain writes for every line of r each number read in mode with its
+// rune offset, and whether it overlaps another one or lies within it,
+// then which numbers count as first and last and the calibration value.
+// It ends with the lines of value 0 and the sum, which it returns.
+func (t *Table) Explain(w io.Writer, r io.Reader, mode Mode, combine Combine) (int, error) {
+    sum, lines := 0, 0
+    zeros := []string{}
+    var writeErr error
+    err := t.scanLines(r, []Mode{mode}, true, func(line []byte, picks []*pick) {
+        lines++
+        p := picks[mode]
+        value := p.value(combine)
+        sum += value
+        if value == 0 {
+            zeros = append(zeros, fmt.Sprint(lines))
+        }
+        if writeErr == nil {
+            writeErr = explainLine(w, lines, line, p, value)
+        }
+    })
+    if err != nil {
+        return 0, err
+    }
+    if writeErr != nil {
+        return 0, writeErr
+    }
+    if len(zeros) == 0 {
+        _, err = fmt.Fprintf(w, "no line of value 0\nsum: %d\n", sum)
+    } else {
+        _, err = fmt.Fprintf(w, "%d of %d lines of value 0: %s\nsum: %d\n", len(zeros), lines, strings.Join(zeros, ", "), sum)
+    }
+    return sum, err
+}
+
+func explainLine(w io.Writer, number int, line []byte, p *pick, value int) error {
+    // the byte index of each rune counted as the scanner does
+    starts := []int{}
+    for i, c := range line {
+        if utf8.RuneStart(c) {
+            starts = append(starts, i)
+        }
+    }
+    starts = append(starts, len(line))
+    text := func(t Token) string {
+        return string(line[starts[t.Offset]:starts[t.Offset + t.Length]])
+    }
+
+    var b strings.Builder
+    fmt.Fprintf(&b, "line %d: %s\n", number, strings.TrimSuffix(string(line), "\r"))
+    tokens := append([]Token{}, p.tokens...)
+    sort.SliceStable(tokens, func(i, j int) bool {
+        if tokens[i].Offset != tokens[j].Offset {
+            return tokens[i].Offset < tokens[j].Offset
+        }
+        return tokens[i].Length > tokens[j].Length
+    })
+    for _, t := range tokens {
+        fmt.Fprintf(&b, "    at %d: %s = %d", t.Offset, text(t), t.Value)
+        end := t.Offset + t.Length
+        for _, other := range tokens {
+            otherEnd := other.Offset + other.Length
+            if other.Offset >= end || otherEnd <= t.Offset || other.Offset == t.Offset && otherEnd == end {
+                continue
+            }
+            if other.Offset <= t.Offset && otherEnd >= end {
+                fmt.Fprintf(&b, ", within %s", text(other))
+                break
+            }
+            if other.Offset < t.Offset || otherEnd > end {
+                fmt.Fprintf(&b, ", overlaps %s", text(other))
+                break
+            }
+        }
+        b.WriteString("\n")
+    }
+    if p.found {
+        fmt.Fprintf(&b, "    first %s at %d, last %s at %d: %d\n", text(p.first), p.first.Offset, text(p.last), p.last.Offset, value)
+    } else {
+        b.WriteString("    no number: 0\n")
+    }
+    _, err := io.WriteString(w, b.String())
+    return err
+}
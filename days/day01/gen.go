@@ -0,0 +1,33 @@
+package day01
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+const GEN_LETTERS = "abcdefghijklmnopqrstuvwxyz"
+
+// Generate returns size lines of letters, digits and spelled out digits,
+// every line has at least one digit.
+func Generate(rng *rand.Rand, size int) []byte {
+    // fixed here rather than taken from ENGLISH, so a seed generates the
+    // same lines whatever the table holds
+    names := []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+    var b bytes.Buffer
+    for i := 0; i < size; i++ {
+        length := 2 + rng.Intn(12)
+        digitAt := rng.Intn(length)
+        for k := 0; k < length; k++ {
+            switch {
+            case k == digitAt || rng.Intn(6) == 0:
+                b.WriteByte(byte('1' + rng.Intn(9)))
+            case rng.Intn(4) == 0:
+                b.WriteString(names[rng.Intn(len(names))])
+            default:
+                b.WriteByte(GEN_LETTERS[rng.Intn(len(GEN_LETTERS))])
+            }
+        }
+        b.WriteByte('\n')
+    }
+    return b.Bytes()
+}
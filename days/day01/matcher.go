@@ -0,0 +1,230 @@
+package day01
+
+import "unicode/utf8"
+
+// Token is a digit or a word found in a line
+type Token struct {
+    Value int
+    // Offset and Length count runes, Offset from the start of the line
+    Offset int
+    Length int
+    Word bool
+}
+
+// matcher is an Aho–Corasick automaton finding the words of a table in
+// a single pass. It runs on the UTF-8 encoding of the words: a valid
+// encoding only matches at rune boundaries, so no line has to be
+// decoded into runes first.
+type matcher struct {
+    // class numbers the bytes occurring in the words from 1, all others
+    // share class 0, which keeps delta small enough for the cache
+    class [256]int32
+    classes int32
+    // delta holds the next state for every state and class, at
+    // state * classes + class, the failure links are already followed
+    delta []int32
+    // word is the index of the word spelled by a state, -1 if none
+    word []int32
+    // dict links to the nearest state on the failure chain spelling a
+    // word, -1 if none
+    dict []int32
+    // out is the state itself if it spells a word, else dict
+    out []int32
+    lengths []int
+    values []int
+}
+
+func newMatcher(words [][]rune, values []int) *matcher {
+    m := &matcher{values: values, classes: 1}
+    for _, word := range words {
+        for _, c := range []byte(string(word)) {
+            if m.class[c] == 0 {
+                m.class[c] = m.classes
+                m.classes++
+            }
+        }
+    }
+    m.addState()
+    for i, word := range words {
+        state := int32(0)
+        for _, c := range []byte(string(word)) {
+            at := state * m.classes + m.class[c]
+            if m.delta[at] < 0 {
+                next := m.addState()
+                m.delta[at] = next
+            }
+            state = m.delta[at]
+        }
+        m.word[state] = int32(i)
+        m.lengths = append(m.lengths, len(word))
+    }
+
+    // breadth first, a failure link always points to a shallower state
+    fail := make([]int32, len(m.word))
+    queue := []int32{}
+    for c := int32(0); c < m.classes; c++ {
+        if next := m.delta[c]; next < 0 {
+            m.delta[c] = 0
+        } else {
+            queue = append(queue, next)
+        }
+    }
+    for len(queue) > 0 {
+        state := queue[0]
+        queue = queue[1:]
+        if f := fail[state]; m.word[f] >= 0 {
+            m.dict[state] = f
+        } else {
+            m.dict[state] = m.dict[f]
+        }
+        for c := int32(0); c < m.classes; c++ {
+            at := state * m.classes + c
+            next := m.delta[at]
+            if next < 0 {
+                m.delta[at] = m.delta[fail[state] * m.classes + c]
+                continue
+            }
+            fail[next] = m.delta[fail[state] * m.classes + c]
+            queue = append(queue, next)
+        }
+    }
+    m.out = make([]int32, len(m.word))
+    for state := range m.out {
+        m.out[state] = m.dict[state]
+        if m.word[state] >= 0 {
+            m.out[state] = int32(state)
+        }
+    }
+    return m
+}
+
+// longest returns the length of the longest word in runes
+func (m *matcher) longest() int {
+    longest := 0
+    for _, length := range m.lengths {
+        longest = max(longest, length)
+    }
+    return longest
+}
+
+func (m *matcher) addState() int32 {
+    for c := int32(0); c < m.classes; c++ {
+        m.delta = append(m.delta, -1)
+    }
+    m.word = append(m.word, -1)
+    m.dict = append(m.dict, -1)
+    return int32(len(m.word) - 1)
+}
+
+// lineScanner reports the tokens of the lines written to it in the
+// order in which they end, the digits 0 to 9 and the words only if words
+// is set, and calls endLine after each line, with its text if keep is
+// set. The offsets assume valid UTF-8.
+type lineScanner struct {
+    matcher *matcher
+    words bool
+    keep bool
+    token func(Token)
+    endLine func(line []byte)
+    state int32
+    // the runes of the line up to and including the current byte
+    runes int
+    pending bool
+    line []byte
+}
+
+func (s *lineScanner) Write(p []byte) (int, error) {
+    m := s.matcher
+    class, classes, delta, word, dict, out := &m.class, m.classes, m.delta, m.word, m.dict, m.out
+    words := s.words
+    state, runes, pending := s.state, s.runes, s.pending
+    from := 0
+    for i, c := range p {
+        if c == '\n' {
+            if s.keep {
+                s.line = append(s.line, p[from:i]...)
+                from = i + 1
+            }
+            s.endLine(s.line)
+            s.line = s.line[:0]
+            state, runes, pending = 0, 0, false
+            continue
+        }
+        pending = true
+        if utf8.RuneStart(c) {
+            runes++
+        }
+        if c >= '0' && c <= '9' {
+            s.token(Token{Value: int(c - '0'), Offset: runes - 1, Length: 1})
+        }
+        if !words {
+            continue
+        }
+        state = delta[state * classes + class[c]]
+        for w := out[state]; w > 0; w = dict[w] {
+            i := word[w]
+            s.token(Token{Value: m.values[i], Offset: runes - m.lengths[i], Length: m.lengths[i], Word: true})
+        }
+    }
+    if s.keep {
+        s.line = append(s.line, p[from:]...)
+    }
+    s.state, s.runes, s.pending = state, runes, pending
+    return len(p), nil
+}
+
+// Close ends the last line, which need not end in a newline
+func (s *lineScanner) Close() error {
+    if s.pending {
+        s.endLine(s.line)
+        s.state, s.runes, s.pending, s.line = 0, 0, false, s.line[:0]
+    }
+    return nil
+}
+
+// pick keeps the first and the last token of a line. Of two tokens at
+// the same offset the longer one counts, words can overlap. With ends
+// the last token is the one ending last, of numerals that grow to the
+// left the whole one. With keep it also keeps every token in tokens.
+type pick struct {
+    ends bool
+    keep bool
+    first Token
+    last Token
+    found bool
+    tokens []Token
+}
+
+func (p *pick) add(t Token) {
+    if p.keep {
+        p.tokens = append(p.tokens, t)
+    }
+    if !p.found {
+        p.first, p.last, p.found = t, t, true
+        return
+    }
+    if t.Offset < p.first.Offset || t.Offset == p.first.Offset && t.Length > p.first.Length {
+        p.first = t
+    }
+    if p.ends {
+        end, lastEnd := t.Offset + t.Length, p.last.Offset + p.last.Length
+        if end > lastEnd || end == lastEnd && t.Length > p.last.Length {
+            p.last = t
+        }
+    } else if t.Offset > p.last.Offset || t.Offset == p.last.Offset && t.Length > p.last.Length {
+        p.last = t
+    }
+}
+
+// reset forgets the tokens of the last line
+func (p *pick) reset() {
+    p.first, p.last, p.found, p.tokens = Token{}, Token{}, false, p.tokens[:0]
+}
+
+// value returns the calibration value, 0 without any token
+func (p *pick) value(combine Combine) int {
+    if !p.found {
+        return 0
+    }
+    return combine(p.first.Value, p.last.Value)
+}
@@ -0,0 +1,193 @@
+package day01
+
+import "stefanvonderkrone/adventOfCode2023/puzzle"
+
+// MAX_DIGITS bounds a digit run in COMPOUND mode, so that two numbers
+// still concatenate to an int
+const MAX_DIGITS = 9
+
+// Combine returns the calibration value of a line from its first and
+// its last number
+type Combine func(first int, last int) int
+
+var (
+    // TENS is the rule of the puzzle, a first number above 9 carries over
+    TENS Combine = func(first int, last int) int {
+        return first * 10 + last
+    }
+    // CONCAT writes the digits of last after those of first
+    CONCAT Combine = func(first int, last int) int {
+        for rest := last / 10; rest > 0; rest /= 10 {
+            first *= 10
+        }
+        return first * 10 + last
+    }
+    // SUM adds the two numbers
+    SUM Combine = func(first int, last int) int {
+        return first + last
+    }
+)
+
+// Combines holds the rules by name
+var Combines = map[string]Combine{
+    "tens": TENS,
+    "concat": CONCAT,
+    "sum": SUM,
+}
+
+// the stages of the current group of a numeral, the part below a
+// thousand between two scales
+const (
+    groupEmpty = iota
+    // a unit, which hundred multiplies
+    groupUnit
+    // tens, a teen or a unit may follow
+    groupHundred
+    // a unit may follow
+    groupTens
+    // only a scale may follow
+    groupFull
+    // a word of no other part, which stands alone
+    groupOther
+)
+
+// numeral is a compound numeral like twentythree or onehundredfive read
+// up to some offset. Its words play their part by value: 1 to 9 are
+// units, 10 to 19 teens, 20 to 90 tens, then hundred and the scales
+// thousand, million and so on, decreasing. Words of any other value are
+// numerals on their own.
+type numeral struct {
+    start int
+    // the groups already multiplied by their scale
+    total int
+    group int
+    stage int
+    // the last scale, 0 before the first
+    scale int
+}
+
+func isScale(value int) bool {
+    if value < 1000 {
+        return false
+    }
+    for value % 1000 == 0 {
+        value /= 1000
+    }
+    return value == 1
+}
+
+// step returns the numeral continued by a word of value, false if
+// English does not say it that way. Every numeral step returns is whole.
+func (n numeral) step(value int) (numeral, bool) {
+    switch {
+    case value >= 1 && value <= 9:
+        switch n.stage {
+        case groupEmpty:
+            n.group, n.stage = value, groupUnit
+        case groupHundred, groupTens:
+            n.group, n.stage = n.group + value, groupFull
+        default:
+            return n, false
+        }
+    case value >= 10 && value <= 19:
+        if n.stage != groupEmpty && n.stage != groupHundred {
+            return n, false
+        }
+        n.group, n.stage = n.group + value, groupFull
+    case value >= 20 && value <= 90 && value % 10 == 0:
+        if n.stage != groupEmpty && n.stage != groupHundred {
+            return n, false
+        }
+        n.group, n.stage = n.group + value, groupTens
+    case value == 100:
+        if n.stage != groupUnit {
+            return n, false
+        }
+        n.group, n.stage = n.group * 100, groupHundred
+    case isScale(value):
+        if n.stage == groupEmpty || n.stage == groupOther || n.scale != 0 && value >= n.scale {
+            return n, false
+        }
+        n.total, n.group, n.stage, n.scale = n.total + n.group * value, 0, groupEmpty, value
+    default:
+        if n.stage != groupEmpty || n.scale != 0 {
+            return n, false
+        }
+        n.group, n.stage = value, groupOther
+    }
+    return n, true
+}
+
+func (n numeral) value() int {
+    return n.total + n.group
+}
+
+// numerals joins the tokens of the lines, in the order in which they
+// end, into digit runs and compound numerals and passes every one of
+// them on, growing numbers again with each digit or word
+type numerals struct {
+    token func(Token)
+    // the digit run up to the last digit
+    digits Token
+    // the numerals ending at each of the last offsets up to end, by
+    // offset modulo its length, which exceeds the longest word
+    ending [][]numeral
+    end int
+}
+
+func newNumerals(longest int, token func(Token)) *numerals {
+    return &numerals{token: token, ending: make([][]numeral, longest + 1)}
+}
+
+func (n *numerals) add(t Token) error {
+    if !t.Word {
+        if n.digits.Length > 0 && n.digits.Offset + n.digits.Length == t.Offset {
+            n.digits.Value = n.digits.Value * 10 + t.Value
+            n.digits.Length++
+        } else {
+            n.digits = t
+        }
+        if n.digits.Length > MAX_DIGITS {
+            return puzzle.Errorf(n.digits.Offset + 1, "", "number of more than %d digits", MAX_DIGITS)
+        }
+        n.token(n.digits)
+        return nil
+    }
+    size := len(n.ending)
+    end := t.Offset + t.Length
+    if end - n.end > size {
+        n.end = end - size
+    }
+    for ; n.end < end; n.end++ {
+        n.ending[(n.end + 1) % size] = n.ending[(n.end + 1) % size][:0]
+    }
+    // t starts within the last offsets, its own numeral starts there too
+    ending := n.ending[end % size]
+    for _, before := range append(n.ending[t.Offset % size], numeral{start: t.Offset}) {
+        next, ok := before.step(t.Value)
+        if !ok || containsNumeral(ending, next) {
+            continue
+        }
+        ending = append(ending, next)
+        n.token(Token{Value: next.value(), Offset: next.start, Length: end - next.start, Word: true})
+    }
+    n.ending[end % size] = ending
+    return nil
+}
+
+func containsNumeral(numerals []numeral, n numeral) bool {
+    for _, other := range numerals {
+        if other == n {
+            return true
+        }
+    }
+    return false
+}
+
+func (n *numerals) endLine() {
+    n.digits = Token{}
+    for i := range n.ending {
+        n.ending[i] = n.ending[i][:0]
+    }
+    n.end = 0
+}
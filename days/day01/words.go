@@ -0,0 +1,142 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+// Table holds the spelled out numbers of a language. Words are compared
+// rune by rune, a table and the documents read with it have to use the
+// same Unicode normalization form, usually NFC.
+type Table struct {
+    // words sorted longest first, so the longest word at an index wins
+    words [][]rune
+    values []int
+    matcher *matcher
+}
+
+// NewTable returns the table of words, which may be of any language.
+// A value above 9 carries over into the tens of a calibration value.
+func NewTable(words map[string]int) (*Table, error) {
+    t := &Table{}
+    names := []string{}
+    for word, value := range words {
+        if word == "" || !utf8.ValidString(word) {
+            return nil, fmt.Errorf("invalid word %q", word)
+        }
+        if strings.ContainsAny(word, "123456789") {
+            return nil, fmt.Errorf("word '%s' contains a digit", word)
+        }
+        if value < 0 {
+            return nil, fmt.Errorf("word '%s' has negative value %d", word, value)
+        }
+        names = append(names, word)
+    }
+    sort.Slice(names, func(i, j int) bool {
+        li, lj := utf8.RuneCountInString(names[i]), utf8.RuneCountInString(names[j])
+        if li != lj {
+            return li > lj
+        }
+        return names[i] < names[j]
+    })
+    for _, name := range names {
+        t.words = append(t.words, []rune(name))
+        t.values = append(t.values, words[name])
+    }
+    t.matcher = newMatcher(t.words, t.values)
+    return t, nil
+}
+
+func mustTable(words map[string]int) *Table {
+    t, err := NewTable(words)
+    if err != nil {
+        panic(err)
+    }
+    return t
+}
+
+var (
+    ENGLISH = mustTable(map[string]int{
+        "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+        "six": 6, "seven": 7, "eight": 8, "nine": 9,
+    })
+    GERMAN = mustTable(map[string]int{
+        "eins": 1, "zwei": 2, "drei": 3, "vier": 4, "fünf": 5,
+        "sechs": 6, "sieben": 7, "acht": 8, "neun": 9,
+    })
+    FRENCH = mustTable(map[string]int{
+        "un": 1, "deux": 2, "trois": 3, "quatre": 4, "cinq": 5,
+        "six": 6, "sept": 7, "huit": 8, "neuf": 9,
+    })
+    // NUMERALS holds the English words of compound numerals, see COMPOUND
+    NUMERALS = mustTable(map[string]int{
+        "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+        "six": 6, "seven": 7, "eight": 8, "nine": 9,
+        "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+        "fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+        "twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+        "sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+        "hundred": 100, "thousand": 1000, "million": 1000000,
+    })
+)
+
+// Tables holds the built-in tables, the languages by their code
+var Tables = map[string]*Table{
+    "en": ENGLISH,
+    "de": GERMAN,
+    "fr": FRENCH,
+    "numerals": NUMERALS,
+}
+
+// LoadTable reads a table of one '<word> <value>' per line. Empty lines
+// and lines starting with '#' are skipped.
+func LoadTable(r io.Reader) (*Table, error) {
+    scanner := puzzle.NewScanner(r)
+    words := map[string]int{}
+    for scanner.Scan() {
+        line := scanner.Text()
+        if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+            continue
+        }
+        fields := puzzle.SplitFields(line, " ")
+        if len(fields) != 2 {
+            return nil, puzzle.AtLine(puzzle.Errorf(1, line, "expected '<word> <value>', found"), scanner.Line)
+        }
+        value, err := fields[1].Atoi()
+        if err != nil {
+            return nil, puzzle.AtLine(err, scanner.Line)
+        }
+        if _, ok := words[fields[0].Text]; ok {
+            return nil, puzzle.AtLine(fields[0].Errorf("duplicate word"), scanner.Line)
+        }
+        words[fields[0].Text] = value
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+    return NewTable(words)
+}
+
+// OpenTable returns the built-in table of a language code like "de" or
+// else loads the table in the file of that name.
+func OpenTable(name string) (*Table, error) {
+    if t, ok := Tables[name]; ok {
+        return t, nil
+    }
+    f, err := os.Open(name)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+    t, err := LoadTable(f)
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", name, err)
+    }
+    return t, nil
+}
@@ -0,0 +1,296 @@
+package day02
+
+import (
+	"context"
+	"io"
+
+	"stefanvonderkrone/adventOfCode2023/lexer"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+const (
+    GAME = "Game"
+    RED = "RED"
+    GREEN = "GREEN"
+    BLUE = "BLUE"
+    COLON = ":"
+    SEMICOLON = ";"
+    COMMA = ","
+    INT = lexer.INT
+    EOL = lexer.EOL
+)
+
+var config = lexer.Config{
+    Keywords: map[string]lexer.TokenType {
+        "Game":   GAME,
+        "red":    RED,
+        "green":  GREEN,
+        "blue":   BLUE,
+    },
+    Punctuation: map[rune]lexer.TokenType {
+        ':': COLON,
+        ';': SEMICOLON,
+        ',': COMMA,
+    },
+}
+
+type Color string;
+
+const (
+    COLOR_RED = "red"
+    COLOR_GREEN = "green"
+    COLOR_BLUE = "blue"
+)
+
+var colors = map[string]Color {
+    "red": COLOR_RED,
+    "green": COLOR_GREEN,
+    "blue": COLOR_BLUE,
+}
+
+type RevealStatement struct {
+    Color   Color
+    Amount  int
+}
+
+type SubsetStatement struct {
+    Reveals []RevealStatement
+}
+
+type GameStatement struct {
+    GameId  int
+    Subsets []SubsetStatement
+}
+
+type Parser struct {
+    lexer *lexer.Lexer
+    curToken lexer.Token
+    peekToken lexer.Token
+}
+
+func newParser(lexer *lexer.Lexer) (*Parser, error) {
+    p := &Parser{lexer: lexer}
+
+    if err := p.nextToken(); err != nil {
+        return nil, err
+    }
+    if err := p.nextToken(); err != nil {
+        return nil, err
+    }
+
+    return p, nil
+}
+
+func (p *Parser) nextToken() error {
+    token, err := p.lexer.NextToken()
+    if err != nil {
+        return err
+    }
+    p.curToken = p.peekToken
+    p.peekToken = token
+    return nil
+}
+
+func (p *Parser) curTokenIs(tokenType lexer.TokenType) bool {
+    return p.curToken.Type == tokenType
+}
+
+func (p *Parser) peekTokenIs(tokenType lexer.TokenType) bool {
+    return p.peekToken.Type == tokenType
+}
+
+func (p *Parser) expectPeekToken(tokenType lexer.TokenType) error {
+    if !p.peekTokenIs(tokenType) {
+        return p.peekToken.Errorf("expected token '%s', found", tokenType)
+    }
+    return p.nextToken()
+}
+
+func (p *Parser) parseGame() ([]GameStatement, error) {
+    statements := []GameStatement{}
+    for !p.curTokenIs(EOL) {
+        statement, err := p.parseGameStatement()
+        if err != nil {
+            return nil, err
+        }
+        statements = append(statements, statement)
+    }
+    return statements, nil
+}
+
+func (p *Parser) parseGameStatement() (GameStatement, error) {
+    if !p.curTokenIs(GAME) {
+        return GameStatement{}, p.curToken.Errorf("unexpected statement")
+    }
+    if err := p.expectPeekToken(INT); err != nil {
+        return GameStatement{}, err
+    }
+    id, err := p.curToken.Atoi()
+    if err != nil {
+        return GameStatement{}, err
+    }
+    if err := p.expectPeekToken(COLON); err != nil {
+        return GameStatement{}, err
+    }
+    subsets, err := p.parseSubsetStatements()
+    if err != nil {
+        return GameStatement{}, err
+    }
+    return GameStatement{GameId: id, Subsets: subsets}, nil
+}
+
+func (p *Parser) parseSubsetStatements() ([]SubsetStatement, error) {
+    subsets := []SubsetStatement{}
+    for !p.curTokenIs(EOL) {
+        subset, err := p.parseSubsetStatement()
+        if err != nil {
+            return nil, err
+        }
+        subsets = append(subsets, subset)
+    }
+    return subsets, nil
+}
+
+func (p *Parser) parseSubsetStatement() (SubsetStatement, error) {
+    reveals := []RevealStatement{}
+    for !p.curTokenIs(EOL) {
+        reveal, err := p.parseRevealStatement()
+        if err != nil {
+            return SubsetStatement{}, err
+        }
+        reveals = append(reveals, reveal)
+        if p.curTokenIs(SEMICOLON) {
+            break;
+        }
+    }
+    return SubsetStatement{Reveals: reveals}, nil
+}
+
+func (p *Parser) parseRevealStatement() (RevealStatement, error) {
+    if err := p.expectPeekToken(INT); err != nil {
+        return RevealStatement{}, err
+    }
+    amount, err := p.curToken.Atoi()
+    if err != nil {
+        return RevealStatement{}, err
+    }
+    if p.peekTokenIs(GREEN) || p.peekTokenIs(RED) || p.peekTokenIs(BLUE) {
+        if err := p.nextToken(); err != nil {
+            return RevealStatement{}, err
+        }
+    }
+    color, ok := colors[p.curToken.Literal]
+    if !ok {
+        return RevealStatement{}, p.curToken.Errorf("unexpected color")
+    }
+    if err := p.nextToken(); err != nil {
+        return RevealStatement{}, err
+    }
+    return RevealStatement{Color: color, Amount: amount}, nil
+}
+
+type Subset struct {
+    Red     int
+    Green   int
+    Blue    int
+}
+
+type Game struct {
+    GameId  int
+    Subsets []Subset
+}
+
+func parseGame(line string) (Game, error) {
+    parser, err := newParser(lexer.New(line, config))
+    if err != nil {
+        return Game{}, err
+    }
+    statements, err := parser.parseGame()
+    if err != nil {
+        return Game{}, err
+    }
+    if len(statements) == 0 {
+        return Game{}, puzzle.Errorf(1, line, "got no statements")
+    }
+    gameStatement := statements[0]
+    subsets := []Subset{}
+    for _, statement := range gameStatement.Subsets {
+        subset := Subset{}
+        for _, revealStatement := range statement.Reveals {
+            switch(revealStatement.Color) {
+            case COLOR_RED:
+                subset.Red += revealStatement.Amount
+            case COLOR_GREEN:
+                subset.Green += revealStatement.Amount
+            case COLOR_BLUE:
+                subset.Blue += revealStatement.Amount
+            }
+        }
+        subsets = append(subsets, subset)
+    }
+    return Game{GameId: gameStatement.GameId, Subsets: subsets}, nil
+}
+
+func isValidGame(game Game, predicate Subset) bool {
+    for _, subset := range game.Subsets {
+        if subset.Red > predicate.Red || subset.Green > predicate.Green || subset.Blue > predicate.Blue {
+            return false
+        }
+    }
+    return true
+}
+
+func maxInt(i1 int, i2 int) int {
+    if i1 > i2 {
+        return i1
+    }
+    return i2
+}
+
+func calculatePower(subsets []Subset) int {
+    red := 0
+    green := 0
+    blue := 0
+    for _, subset := range subsets {
+        red = maxInt(red, subset.Red)
+        green = maxInt(green, subset.Green)
+        blue = maxInt(blue, subset.Blue)
+    }
+    return red * green * blue
+}
+
+func readGames(r io.Reader) ([]Game, error) {
+    scanner := puzzle.NewScanner(r)
+
+    games := []Game{}
+    for scanner.Scan() {
+        line := scanner.Text()
+        game, err := parseGame(line)
+        if err != nil {
+            return nil, puzzle.AtLine(err, scanner.Line)
+        }
+        games = append(games, game)
+    }
+    return games, scanner.Err()
+}
+
+func solve(games []Game) puzzle.Result {
+    sum := 0
+    sumOfPowers := 0
+    predicate := Subset{Red: 12, Green: 13, Blue: 14}
+    for _, game := range games {
+        if isValidGame(game, predicate) {
+            sum += game.GameId
+        }
+        sumOfPowers += calculatePower(game.Subsets)
+    }
+    return puzzle.Result{Part1: sum, Part2: sumOfPowers}
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    games, err := readGames(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    return solve(games), nil
+}
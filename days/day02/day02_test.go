@@ -0,0 +1,45 @@
+package day02
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day02.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, err := readGames(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    games, err := readGames(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        solve(games)
+    }
+}
+
+func FuzzParseGame(f *testing.F) {
+    for _, line := range strings.Split(string(readExample(f)), "\n") {
+        f.Add(line)
+    }
+    f.Fuzz(func(t *testing.T, line string) {
+        parseGame(line)
+    })
+}
@@ -0,0 +1,26 @@
+package day02
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Generate returns size games of one to six subsets.
+func Generate(rng *rand.Rand, size int) []byte {
+    colors := []string{COLOR_RED, COLOR_GREEN, COLOR_BLUE}
+    var b bytes.Buffer
+    for id := 1; id <= size; id++ {
+        subsets := []string{}
+        for s := 1 + rng.Intn(6); s > 0; s-- {
+            reveals := []string{}
+            for _, i := range rng.Perm(len(colors))[:1 + rng.Intn(len(colors))] {
+                reveals = append(reveals, fmt.Sprintf("%d %s", 1 + rng.Intn(20), colors[i]))
+            }
+            subsets = append(subsets, strings.Join(reveals, ", "))
+        }
+        fmt.Fprintf(&b, "Game %d: %s\n", id, strings.Join(subsets, "; "))
+    }
+    return b.Bytes()
+}
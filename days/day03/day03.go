@@ -0,0 +1,100 @@
+package day03
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"stefanvonderkrone/adventOfCode2023/grid"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+const DOT = '.'
+
+func isDigit(char byte) bool {
+    return char >= 48 && char <= 57
+}
+
+func isSymbol(char byte) bool {
+    if isDigit(char) || char == DOT {
+        return false
+    }
+    return true
+}
+
+// readNumberAt returns where the number covering at starts and its value
+func readNumberAt(line []byte, at int) (int, int) {
+    lastIndex := len(line) - 1
+    left := at
+    for left > 0 && isDigit(line[left - 1]) {
+        left--
+    }
+    right := at
+    for right < lastIndex && isDigit(line[right + 1]) {
+        right++
+    }
+    n, err := strconv.Atoi(string(line[left:right + 1]))
+    if err != nil {
+        return left, 0
+    }
+    return left, n
+}
+
+// extractAt returns every number adjacent to the cell at x, y, each once
+func extractAt(g *grid.Grid, x int, y int) []int {
+    numbers := []int{}
+    seen := map[grid.Pos]bool{}
+    for _, p := range g.Neighbours8(x, y) {
+        if !isDigit(g.Get(p.X, p.Y)) {
+            continue
+        }
+        start, n := readNumberAt(g.Row(p.Y), p.X)
+        if seen[grid.Pos{X: start, Y: p.Y}] {
+            continue
+        }
+        seen[grid.Pos{X: start, Y: p.Y}] = true
+        numbers = append(numbers, n)
+    }
+    return numbers
+}
+
+const GEAR = '*'
+
+func extractGearRatioAt(g *grid.Grid, x int, y int) int {
+    numbers := extractAt(g, x, y)
+    if len(numbers) == 2 {
+        a := numbers[0]
+        b := numbers[1]
+        return a * b
+    }
+    return 0
+}
+
+func solve(log *slog.Logger, g *grid.Grid) puzzle.Result {
+    sum := 0
+    sumGR := 0
+    for y := 0; y < g.Height; y++ {
+        for x, char := range g.Row(y) {
+            if isSymbol(char) {
+                numbers := extractAt(g, x, y)
+                log.Debug("symbol", "char", string(char), "x", x, "y", y, "numbers", numbers)
+                for _, number := range numbers {
+                    sum += number
+                }
+            }
+            if char == GEAR {
+                sumGR += extractGearRatioAt(g, x, y)
+            }
+        }
+    }
+    return puzzle.Result{Part1: sum, Part2: sumGR}
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    g, err := grid.Read(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    return solve(puzzle.Logger(ctx), g), nil
+}
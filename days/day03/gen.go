@@ -0,0 +1,35 @@
+package day03
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+)
+
+const GEN_SYMBOLS = "*#+$/=%@&-"
+
+// Generate returns a size x size schematic of numbers, symbols and dots.
+func Generate(rng *rand.Rand, size int) []byte {
+    var b bytes.Buffer
+    for y := 0; y < size; y++ {
+        row := make([]byte, 0, size)
+        for len(row) < size {
+            switch n := rng.Intn(10); {
+            case n == 0:
+                row = append(row, GEN_SYMBOLS[rng.Intn(len(GEN_SYMBOLS))])
+            case n <= 2:
+                number := strconv.Itoa(1 + rng.Intn(999))
+                if len(row) + len(number) > size {
+                    continue
+                }
+                // a dot keeps the next number apart
+                row = append(append(row, number...), DOT)
+            default:
+                row = append(row, DOT)
+            }
+        }
+        b.Write(row[:size])
+        b.WriteByte('\n')
+    }
+    return b.Bytes()
+}
@@ -0,0 +1,156 @@
+package day04
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"golang.org/x/exp/slices"
+
+	"stefanvonderkrone/adventOfCode2023/lexer"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+const (
+    PIPE = "PIPE"
+    EOL = lexer.EOL
+    INT = lexer.INT
+)
+
+var config = lexer.Config{
+    Punctuation: map[rune]lexer.TokenType {
+        '|': PIPE,
+    },
+}
+
+func parseNumbers(l *lexer.Lexer, end lexer.TokenType) ([]int, error) {
+    numbers := []int{}
+    for {
+        token, err := l.NextToken()
+        if err != nil {
+            return nil, err
+        }
+        if token.Type == end {
+            return numbers, nil
+        }
+        if token.Type != INT {
+            return nil, token.Errorf("expected number or %s, found", end)
+        }
+        n, err := token.Atoi()
+        if err != nil {
+            return nil, err
+        }
+        numbers = append(numbers, n)
+    }
+}
+
+type Card struct {
+    winning []int
+    owning []int
+}
+
+func (c *Card) calculatePower() int {
+    power := 0
+    for _, n := range c.owning {
+        if slices.Contains(c.winning, n) {
+            if power == 0 {
+                power = 1
+            } else {
+                power *= 2
+            }
+        }
+    }
+    return power
+}
+
+func (c *Card) calculateScore() int {
+    score := 0
+    for _, n := range c.owning {
+        if slices.Contains(c.winning, n) {
+            score += 1
+        }
+    }
+    return score
+}
+
+type Scoreboard struct {
+    counts []int
+    log *slog.Logger
+}
+
+func newScoreboard(log *slog.Logger) Scoreboard {
+    return Scoreboard{counts: []int{}, log: log}
+}
+
+func (s *Scoreboard) addScoreAt(score int, at int) {
+    requiredLength := at + score + 1
+    for len(s.counts) < requiredLength {
+        s.counts = append(s.counts, 1)
+    }
+    count := s.counts[at]
+    s.log.Debug("score", "card", at + 1, "score", score, "count", count)
+    for n := 0; n < count; n++ {
+        for i := at + 1; i < requiredLength; i++ {
+            s.counts[i]++;
+        }
+    }
+}
+
+func (s *Scoreboard) sum() int {
+    sum := 0
+    for _, n := range s.counts {
+        sum += n
+    }
+    return sum
+}
+
+func parseLine(line string) (Card, error) {
+    l := lexer.New(line, config)
+    if err := l.SkipPast(':'); err != nil {
+        return Card{}, err
+    }
+    winning, err := parseNumbers(l, PIPE)
+    if err != nil {
+        return Card{}, err
+    }
+    owning, err := parseNumbers(l, EOL)
+    if err != nil {
+        return Card{}, err
+    }
+    return Card{winning: winning, owning: owning}, nil
+}
+
+func readCards(r io.Reader) ([]Card, error) {
+    scanner := puzzle.NewScanner(r)
+
+    cards := []Card{}
+    for scanner.Scan() {
+        line := scanner.Text()
+        card, err := parseLine(line)
+        if err != nil {
+            return nil, puzzle.AtLine(err, scanner.Line)
+        }
+        cards = append(cards, card)
+    }
+    return cards, scanner.Err()
+}
+
+func solve(log *slog.Logger, cards []Card) puzzle.Result {
+    sum := 0
+    scoreboard := newScoreboard(log)
+    for index, card := range cards {
+        power := card.calculatePower()
+        sum += power
+        score := card.calculateScore()
+        scoreboard.addScoreAt(score, index)
+    }
+    return puzzle.Result{Part1: sum, Part2: scoreboard.sum()}
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    cards, err := readCards(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    return solve(puzzle.Logger(ctx), cards), nil
+}
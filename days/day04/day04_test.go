@@ -0,0 +1,48 @@
+package day04
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day04.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, err := readCards(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    cards, err := readCards(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        solve(puzzle.Logger(context.Background()), cards)
+    }
+}
+
+func FuzzParseLine(f *testing.F) {
+    for _, line := range strings.Split(string(readExample(f)), "\n") {
+        f.Add(line)
+    }
+    f.Fuzz(func(t *testing.T, line string) {
+        parseLine(line)
+    })
+}
@@ -0,0 +1,57 @@
+package day04
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+    GEN_WINNING = 10
+    GEN_OWNING = 25
+)
+
+// genScore returns how many numbers a card wins. Cards win less than
+// one on average, otherwise the number of copies grows exponentially.
+func genScore(rng *rand.Rand) int {
+    switch n := rng.Intn(100); {
+    case n < 50:
+        return 0
+    case n < 80:
+        return 1
+    case n < 92:
+        return 2
+    case n < 97:
+        return 3
+    }
+    return 4 + rng.Intn(GEN_WINNING - 3)
+}
+
+func genNumbers(numbers []int) string {
+    fields := make([]string, len(numbers))
+    for i, n := range numbers {
+        fields[i] = fmt.Sprintf("%2d", n)
+    }
+    return strings.Join(fields, " ")
+}
+
+// Generate returns size cards, the copies they win never pass the last one.
+func Generate(rng *rand.Rand, size int) []byte {
+    var b bytes.Buffer
+    for id := 1; id <= size; id++ {
+        score := min(genScore(rng), size - id)
+        // the first numbers are winning ones, the matches come from them
+        numbers := rng.Perm(99)[:GEN_WINNING + GEN_OWNING - score]
+        for i := range numbers {
+            numbers[i]++
+        }
+        winning := numbers[:GEN_WINNING]
+        owning := append(numbers[GEN_WINNING:], winning[:score]...)
+        rng.Shuffle(len(owning), func(i, j int) {
+            owning[i], owning[j] = owning[j], owning[i]
+        })
+        fmt.Fprintf(&b, "Card %3d: %s | %s\n", id, genNumbers(winning), genNumbers(owning))
+    }
+    return b.Bytes()
+}
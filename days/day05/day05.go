@@ -0,0 +1,229 @@
+package day05
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"stefanvonderkrone/adventOfCode2023/interval"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+// Range maps the values of Source onto the ones starting at Dest
+type Range struct {
+    Dest int
+    Source interval.Interval
+}
+
+type Category struct {
+    Ranges []Range
+    From string
+    To string
+}
+
+type Garden struct {
+    Seeds []int
+    Relations map[string]Category
+}
+
+func (g *Garden) find(log *slog.Logger, key string, value int) int {
+    cat, ok := g.Relations[key]
+    if !ok {
+        return value
+    }
+    log.Debug("find", "category", key, "value", value)
+    for _, r := range cat.Ranges {
+        if r.Source.Contains(value) {
+            return g.find(log, cat.To, value + r.Dest - r.Source.Start)
+        }
+    }
+    return g.find(log, cat.To, value)
+}
+
+func (g *Garden) findRanges(key string, seedRanges interval.Set) interval.Set {
+    cat, ok := g.Relations[key]
+    if !ok {
+        return seedRanges
+    }
+    mapped := []interval.Interval{}
+    for _, r := range cat.Ranges {
+        unmapped := interval.Set{}
+        for _, sr := range seedRanges {
+            if common := sr.Intersect(r.Source); !common.Empty() {
+                mapped = append(mapped, common.Shift(r.Dest - r.Source.Start))
+            }
+            // the parts outside of this range may still be mapped by another one
+            unmapped = append(unmapped, sr.Difference(r.Source)...)
+        }
+        seedRanges = unmapped
+    }
+    return g.findRanges(cat.To, interval.Union(append(mapped, seedRanges...)...))
+}
+
+func (g *Garden) seedRanges() interval.Set {
+    seedRanges := []interval.Interval{}
+    for i := 0; i + 1 < len(g.Seeds); i += 2 {
+        seedRanges = append(seedRanges, interval.OfLength(g.Seeds[i], g.Seeds[i + 1]))
+    }
+    return interval.Union(seedRanges...)
+}
+
+// checkRelation rejects a second map from the same category and maps
+// leading back to where they started, find would never end on those
+func (g *Garden) checkRelation(cat Category) error {
+    if _, ok := g.Relations[cat.From]; ok {
+        return puzzle.Errorf(1, cat.From, "duplicate map from")
+    }
+    for to := cat.To; ; {
+        if to == cat.From {
+            return puzzle.Errorf(1, cat.From + "-to-" + cat.To, "cyclic map")
+        }
+        next, ok := g.Relations[to]
+        if !ok {
+            return nil
+        }
+        to = next.To
+    }
+}
+
+func parseSeeds(line string) ([]int, error) {
+    fields := puzzle.SplitFields(line, " ")
+    if fields[0].Text != "seeds:" {
+        return nil, fields[0].Errorf("expected 'seeds:', found")
+    }
+    fields = fields[1:]
+    seeds := make([]int, len(fields))
+    for i, field := range fields {
+        seed, err := field.Atoi()
+        if err != nil {
+            return nil, err
+        }
+        seeds[i] = seed
+    }
+    return seeds, nil
+}
+
+func parseFromTo(line string) (string, string, error) {
+    relation := puzzle.SplitFields(line, " ")[0]
+    parts := strings.Split(relation.Text, "-")
+    if len(parts) != 3 || parts[1] != "to" {
+        return "", "", relation.Errorf("expected '<from>-to-<to>', found")
+    }
+    from := parts[0]
+    to := parts[2]
+    return from, to, nil
+}
+
+func parseRange(line string) (Range, error) {
+    fields := puzzle.SplitFields(line, " ")
+    if len(fields) != 3 {
+        return Range{}, puzzle.Errorf(1, line, "expected '<dest> <source> <length>', found")
+    }
+    dest, err := fields[0].Atoi()
+    if err != nil {
+        return Range{}, err
+    }
+    source, err := fields[1].Atoi()
+    if err != nil {
+        return Range{}, err
+    }
+    r, err := fields[2].Atoi()
+    if err != nil {
+        return Range{}, err
+    }
+    return Range{dest, interval.OfLength(source, r)}, nil
+}
+
+func readCategory(scanner *puzzle.Scanner) (Category, error) {
+    cat := Category{Ranges: []Range{}}
+    j := 0
+    line := scanner.Text()
+    for {
+        if line == "" {
+            break
+        }
+        if j == 0 {
+            j++
+            from, to, err := parseFromTo(line)
+            if err != nil {
+                return cat, puzzle.AtLine(err, scanner.Line)
+            }
+            cat.From = from
+            cat.To = to
+        } else {
+            r, err := parseRange(line)
+            if err != nil {
+                return cat, puzzle.AtLine(err, scanner.Line)
+            }
+            cat.Ranges = append(cat.Ranges, r)
+        }
+        if !scanner.Scan() {
+            break
+        }
+        line = scanner.Text()
+    }
+    return cat, nil
+}
+
+func readGarden(r io.Reader) (Garden, error) {
+    scanner := puzzle.NewScanner(r)
+
+    i := 0
+    garden := Garden{Relations: map[string]Category{}}
+    for scanner.Scan() {
+        line := scanner.Text()
+        if i == 0 {
+            i++
+            seeds, err := parseSeeds(line)
+            if err != nil {
+                return garden, puzzle.AtLine(err, scanner.Line)
+            }
+            garden.Seeds = seeds
+            continue
+        }
+        if line == "" {
+            continue
+        }
+        header := scanner.Line
+        currentCategory, err := readCategory(scanner)
+        if err != nil {
+            return garden, err
+        }
+        if err := garden.checkRelation(currentCategory); err != nil {
+            return garden, puzzle.AtLine(err, header)
+        }
+        garden.Relations[currentCategory.From] = currentCategory
+    }
+    return garden, scanner.Err()
+}
+
+func solve(log *slog.Logger, garden Garden) puzzle.Result {
+    for key, cat := range garden.Relations {
+        log.Debug("category", "from", key, "to", cat.To, "ranges", len(cat.Ranges))
+    }
+    minLoc := -1
+    for _, seed := range garden.Seeds {
+        loc := garden.find(log, "seed", seed)
+        log.Debug("location", "seed", seed, "location", loc)
+        if minLoc < 0 || loc < minLoc {
+            minLoc = loc
+        }
+    }
+    minRangeLoc := -1
+    // the locations come back sorted
+    locations := garden.findRanges("seed", garden.seedRanges())
+    log.Debug("location ranges", "ranges", len(locations), "locations", locations.Len())
+    if len(locations) > 0 {
+        minRangeLoc = locations[0].Start
+    }
+    return puzzle.Result{Part1: minLoc, Part2: minRangeLoc}
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    garden, err := readGarden(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    return solve(puzzle.Logger(ctx), garden), nil
+}
@@ -0,0 +1,51 @@
+package day05
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day05.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, err := readGarden(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    garden, err := readGarden(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        solve(puzzle.Logger(context.Background()), garden)
+    }
+}
+
+func FuzzReadGarden(f *testing.F) {
+    f.Add(readExample(f))
+    f.Add([]byte("seeds: 1 2\n\nseed-to-soil map:\n1 1 1\n\nsoil-to-seed map:\n1 1 1\n"))
+    f.Fuzz(func(t *testing.T, input []byte) {
+        garden, err := readGarden(bytes.NewReader(input))
+        if err != nil {
+            return
+        }
+        // the maps must lead somewhere for the solver to end
+        solve(puzzle.Logger(context.Background()), garden)
+    })
+}
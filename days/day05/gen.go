@@ -0,0 +1,58 @@
+package day05
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// the categories of the almanac, in the order of its maps
+var genCategories = []string{"seed", "soil", "fertilizer", "water", "light", "temperature", "humidity", "location"}
+
+const GEN_SPACE = 1 << 32
+
+// genCuts returns count sorted values splitting [0, GEN_SPACE)
+func genCuts(rng *rand.Rand, count int) []int {
+    seen := map[int]bool{0: true}
+    cuts := []int{0}
+    for len(cuts) < count {
+        cut := rng.Intn(GEN_SPACE)
+        if !seen[cut] {
+            seen[cut] = true
+            cuts = append(cuts, cut)
+        }
+    }
+    cuts = append(cuts, GEN_SPACE)
+    sort.Ints(cuts)
+    return cuts
+}
+
+// Generate returns an almanac of size / 4 seed ranges and seven maps of
+// size ranges each. A map splits the values into size pieces and moves
+// them around, a few pieces are left out and keep their values.
+func Generate(rng *rand.Rand, size int) []byte {
+    size = max(size, 1)
+    var b bytes.Buffer
+    b.WriteString("seeds:")
+    // the seeds cover about a sixteenth of the values
+    seeds := max(size / 4, 1)
+    for i := 0; i < seeds; i++ {
+        start := rng.Intn(GEN_SPACE)
+        fmt.Fprintf(&b, " %d %d", start, 1 + rng.Intn(min(GEN_SPACE - start, GEN_SPACE / 8 / seeds)))
+    }
+    b.WriteString("\n")
+    for c := 0; c + 1 < len(genCategories); c++ {
+        fmt.Fprintf(&b, "\n%s-to-%s map:\n", genCategories[c], genCategories[c + 1])
+        cuts := genCuts(rng, size)
+        dest := 0
+        for _, i := range rng.Perm(size) {
+            length := cuts[i + 1] - cuts[i]
+            if rng.Intn(10) > 0 {
+                fmt.Fprintf(&b, "%d %d %d\n", dest, cuts[i], length)
+            }
+            dest += length
+        }
+    }
+    return b.Bytes()
+}
@@ -0,0 +1,220 @@
+package day07
+
+import (
+	"context"
+	"io"
+	"math"
+	"sort"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+type HandType int
+
+const (
+    FIVE_OF_A_KIND = 10000
+    FOUR_OF_A_KIND = 1001
+    FULL_HOUSE = 110
+    THREE_OF_A_KIND = 102
+    TWO_PAIR = 21
+    ONE_PAIR = 13
+    HIGH_CARD = 5
+)
+
+type CardType int
+
+const (
+    ASS = 14
+    KING = 13
+    QUEEN = 12
+    JACK = 11
+    TEN = 10
+    NINE = 9
+    EIGHT = 8
+    SEVEN = 7
+    SIX = 6
+    FIVE = 5
+    FOUR = 4
+    THREE = 3
+    TWO = 2
+    JOKER = 1
+)
+
+var cardsMap = map[rune]CardType {
+    'A': ASS,
+    'K': KING,
+    'Q': QUEEN,
+    'J': JACK,
+    'T': TEN,
+    '9': NINE,
+    '8': EIGHT,
+    '7': SEVEN,
+    '6': SIX,
+    '5': FIVE,
+    '4': FOUR,
+    '3': THREE,
+    '2': TWO,
+}
+
+func upgradeHandType(ht HandType, numJs int) HandType {
+    switch(ht) {
+        case HIGH_CARD:
+            return ONE_PAIR
+        case ONE_PAIR:
+            return THREE_OF_A_KIND
+        case TWO_PAIR:
+            if numJs == 2 {
+                return FOUR_OF_A_KIND
+            }
+            return FULL_HOUSE
+        case THREE_OF_A_KIND:
+            return FOUR_OF_A_KIND
+        case FULL_HOUSE:
+            return FIVE_OF_A_KIND
+        case FOUR_OF_A_KIND:
+            return FIVE_OF_A_KIND
+        case FIVE_OF_A_KIND:
+            return FIVE_OF_A_KIND
+    }
+    return ht
+}
+
+func handType(hand []CardType) HandType {
+    cardCounts := make([]int, 15)
+    numJs := 0;
+    for _, cardType := range hand {
+        cardCounts[cardType]++
+        if cardType == JOKER {
+            numJs++;
+        }
+    }
+    counts := make([]int, len(hand))
+    for _, count := range cardCounts {
+        if count > 0 {
+            counts[count - 1]++
+        }
+    }
+    hash := 0
+    for i, n := range counts {
+        hash += n * int(math.Pow10(i))
+    }
+    ht := HandType(hash)
+    if numJs > 0 {
+        ht = upgradeHandType(ht, numJs)
+    }
+    return ht
+}
+
+const HAND_SIZE = 5
+
+func parseHand(hand []rune, withJokers bool) ([]CardType, error) {
+    if len(hand) != HAND_SIZE {
+        return nil, puzzle.Errorf(1, string(hand), "expected %d cards, found", HAND_SIZE)
+    }
+    cards := make([]CardType, len(hand))
+    for index, card := range hand {
+        if cardType, ok := cardsMap[hand[index]]; ok {
+            if withJokers && cardType == JACK {
+                cardType = JOKER
+            }
+            cards[index] = cardType
+        } else {
+            return nil, puzzle.Errorf(index + 1, string([]rune{card}), "unknown card")
+        }
+    }
+    return cards, nil
+}
+
+func parseCard(line string, withJokers bool) (Card, error) {
+    card := Card{}
+    lineR := []rune(line)
+    index := 0
+    for index < len(lineR) && lineR[index] != ' ' {
+        index++;
+    }
+    if index == len(lineR) {
+        return card, puzzle.Errorf(index + 1, "", "missing bid")
+    }
+    hand, err := parseHand(lineR[0:index], withJokers)
+    if err != nil {
+        return card, err
+    }
+    card.Hand = hand
+    index++
+    bid, err := puzzle.Field{Text: string(lineR[index:]), Column: index + 1}.Atoi()
+    if err != nil {
+        return card, err
+    }
+    card.Bid = bid
+    card.Type = handType(card.Hand)
+    return card, nil
+}
+
+type Card struct {
+    Hand []CardType
+    Bid int
+    Type HandType
+}
+
+type Cards []Card
+
+func (c Cards) Len() int {
+    return len(c)
+}
+
+func (c Cards) Swap(i, j int) {
+    c[j], c[i] = c[i], c[j]
+}
+
+func (c Cards) Less(i, j int) bool {
+    card1 := c[i]
+    card2 := c[j]
+    if card1.Type == card2.Type {
+        for i := 0; i < len(card1.Hand); i++ {
+            if card1.Hand[i] == card2.Hand[i] {
+                continue
+            }
+            return card1.Hand[i] < card2.Hand[i]
+        }
+    }
+    return card1.Type < card2.Type
+}
+
+func totalWinnings(cards []Card) int {
+    sort.Sort(Cards(cards))
+    sum := 0
+    for i, card := range cards {
+        sum += (i+1) * card.Bid
+    }
+    return sum
+}
+
+// readCards reads every card twice, without and with jokers
+func readCards(r io.Reader) ([]Card, []Card, error) {
+    scanner := puzzle.NewScanner(r)
+
+    cards := []Card{}
+    jokerCards := []Card{}
+    for scanner.Scan() {
+        line := scanner.Text()
+        card, err := parseCard(line, false)
+        if err != nil {
+            return nil, nil, puzzle.AtLine(err, scanner.Line)
+        }
+        jokerCard, err := parseCard(line, true)
+        if err != nil {
+            return nil, nil, puzzle.AtLine(err, scanner.Line)
+        }
+        cards = append(cards, card)
+        jokerCards = append(jokerCards, jokerCard)
+    }
+    return cards, jokerCards, scanner.Err()
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    cards, jokerCards, err := readCards(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    return puzzle.Result{Part1: totalWinnings(cards), Part2: totalWinnings(jokerCards)}, nil
+}
@@ -0,0 +1,173 @@
+package day07
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/prop"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day07.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, _, err := readCards(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    cards, jokerCards, err := readCards(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    // totalWinnings sorts in place, every iteration starts unsorted
+    unsorted := make([]Card, len(cards))
+    unsortedJokers := make([]Card, len(jokerCards))
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        copy(unsorted, cards)
+        copy(unsortedJokers, jokerCards)
+        totalWinnings(unsorted)
+        totalWinnings(unsortedJokers)
+    }
+}
+
+func FuzzParseCard(f *testing.F) {
+    for _, line := range strings.Split(string(readExample(f)), "\n") {
+        f.Add(line)
+    }
+    f.Fuzz(func(t *testing.T, line string) {
+        for _, withJokers := range []bool{false, true} {
+            card, err := parseCard(line, withJokers)
+            if err == nil && len(card.Hand) != HAND_SIZE {
+                t.Errorf("parseCard(%q, %t) returned %d cards", line, withJokers, len(card.Hand))
+            }
+        }
+    })
+}
+
+// referenceHandType classifies hand by the sizes of its groups, a joker
+// becomes whichever card makes the best hand
+func referenceHandType(hand []CardType) HandType {
+    for i, card := range hand {
+        if card != JOKER {
+            continue
+        }
+        best := HandType(0)
+        for _, card := range cardsMap {
+            if card == JACK {
+                continue
+            }
+            replaced := append([]CardType{}, hand...)
+            replaced[i] = card
+            best = max(best, referenceHandType(replaced))
+        }
+        return best
+    }
+    counts := map[CardType]int{}
+    for _, card := range hand {
+        counts[card]++
+    }
+    groups := []int{}
+    for _, n := range counts {
+        groups = append(groups, n)
+    }
+    sort.Sort(sort.Reverse(sort.IntSlice(groups)))
+    switch {
+    case groups[0] == 5:
+        return FIVE_OF_A_KIND
+    case groups[0] == 4:
+        return FOUR_OF_A_KIND
+    case groups[0] == 3 && groups[1] == 2:
+        return FULL_HOUSE
+    case groups[0] == 3:
+        return THREE_OF_A_KIND
+    case groups[0] == 2 && groups[1] == 2:
+        return TWO_PAIR
+    case groups[0] == 2:
+        return ONE_PAIR
+    }
+    return HIGH_CARD
+}
+
+// referenceWinnings ranks each card by counting the weaker ones
+func referenceWinnings(cards []Card) int {
+    types := make([]HandType, len(cards))
+    for i, card := range cards {
+        types[i] = referenceHandType(card.Hand)
+    }
+    weaker := func(i int, k int) bool {
+        a, b := cards[i], cards[k]
+        if types[i] != types[k] {
+            return types[i] < types[k]
+        }
+        for i := range a.Hand {
+            if a.Hand[i] != b.Hand[i] {
+                return a.Hand[i] < b.Hand[i]
+            }
+        }
+        return false
+    }
+    sum := 0
+    for i, card := range cards {
+        rank := 1
+        for k := range cards {
+            if weaker(k, i) {
+                rank++
+            }
+        }
+        sum += rank * card.Bid
+    }
+    return sum
+}
+
+func TestReferenceHandType(t *testing.T) {
+    prop.Check(t, func(rng *rand.Rand) error {
+        // plenty of jokers
+        hand := make([]rune, HAND_SIZE)
+        for i := range hand {
+            hand[i] = rune(("JJJ" + GEN_CARDS)[rng.Intn(len(GEN_CARDS) + 3)])
+        }
+        for _, withJokers := range []bool{false, true} {
+            cards, err := parseHand(hand, withJokers)
+            if err != nil {
+                return err
+            }
+            if got, want := handType(cards), referenceHandType(cards); got != want {
+                return fmt.Errorf("%s with jokers %t: type %d, want %d", string(hand), withJokers, got, want)
+            }
+        }
+        return nil
+    })
+}
+
+func TestReferenceWinnings(t *testing.T) {
+    prop.Check(t, func(rng *rand.Rand) error {
+        input := Generate(rng, 1 + rng.Intn(20))
+        cards, jokerCards, err := readCards(bytes.NewReader(input))
+        if err != nil {
+            return err
+        }
+        for _, cards := range [][]Card{cards, jokerCards} {
+            want := referenceWinnings(cards)
+            if got := totalWinnings(cards); got != want {
+                return fmt.Errorf("winnings %d, want %d\n%s", got, want, input)
+            }
+        }
+        return nil
+    })
+}
@@ -0,0 +1,30 @@
+package day07
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+const GEN_CARDS = "AKQJT98765432"
+
+// Generate returns size distinct hands with bids. A tie between equal
+// hands would leave their ranks and so the winnings undecided.
+func Generate(rng *rand.Rand, size int) []byte {
+    // there are only that many hands
+    size = min(size, 371293)
+    var b bytes.Buffer
+    seen := map[string]bool{}
+    for len(seen) < size {
+        hand := make([]byte, HAND_SIZE)
+        for i := range hand {
+            hand[i] = GEN_CARDS[rng.Intn(len(GEN_CARDS))]
+        }
+        if seen[string(hand)] {
+            continue
+        }
+        seen[string(hand)] = true
+        fmt.Fprintf(&b, "%s %d\n", hand, 1 + rng.Intn(1000))
+    }
+    return b.Bytes()
+}
@@ -0,0 +1,332 @@
+package day08
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+
+	"stefanvonderkrone/adventOfCode2023/mathx"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+type Pair struct {
+    Left string
+    Right string
+}
+
+func isLetter(char byte) bool {
+    return (char >= 48 && char <= 57) || (char >= 65 && char <= 90)
+}
+
+func readWordAt(line string, at int) (string, int, error) {
+    start := at
+    for start < len(line) && !isLetter(line[start]) {
+        start++
+    }
+    end := start
+    for end < len(line) && isLetter(line[end]) {
+        end++;
+    }
+    if start == end {
+        return "", end, puzzle.Errorf(start + 1, "", "expected a node")
+    }
+    return line[start:end], end, nil
+}
+
+// parseLine returns the node of a line and where it leads, together
+// with the columns of both targets
+func parseLine(line string) (string, Pair, []puzzle.Field, error) {
+    key, end, err := readWordAt(line, 0)
+    if err != nil {
+        return key, Pair{}, nil, err
+    }
+    pair := Pair{}
+    left, endLeft, err := readWordAt(line, end)
+    if err != nil {
+        return key, pair, nil, err
+    }
+    right, endRight, err := readWordAt(line, endLeft)
+    if err != nil {
+        return key, pair, nil, err
+    }
+    pair.Left = left
+    pair.Right = right
+    targets := []puzzle.Field{
+        {Text: left, Column: endLeft - len(left) + 1},
+        {Text: right, Column: endRight - len(right) + 1},
+    }
+    return key, pair, targets, nil
+}
+
+func solvePt1(ctx context.Context, instructions []rune, coordinates map[string]Pair) (int, error) {
+    key := "AAA"
+    current, ok := coordinates[key]
+    if !ok {
+        return 0, nil
+    }
+    log := puzzle.Logger(ctx)
+    // a step is too cheap to pay for building its event when not logged
+    debug := log.Enabled(ctx, slog.LevelDebug)
+    steps := 0
+    instructionIndex := 0
+    numInstructions := len(instructions)
+    for key != "ZZZ" {
+        if debug {
+            log.Debug("step", "steps", steps, "node", key, "left", current.Left, "right", current.Right)
+        }
+        if steps % puzzle.CHECK_EVERY == 0 {
+            if err := puzzle.Interrupt(ctx, 1, "%d steps", steps); err != nil {
+                return 0, err
+            }
+        }
+        steps++
+        instruction := instructions[instructionIndex]
+        if instruction == 'L' {
+            key = current.Left
+        } else {
+            key = current.Right
+        }
+        current, _ = coordinates[key]
+        instructionIndex++
+        if instructionIndex >= numInstructions {
+            instructionIndex = 0
+        }
+    }
+    return steps, nil
+}
+
+func endsWith(word string, char byte) bool {
+    return word[len(word) - 1] == char
+}
+
+func allEndWith(words []string, char byte) bool {
+    for _, word := range words {
+        if !endsWith(word, char) {
+            return false
+        }
+    }
+    return true
+}
+
+// ghost tells when a ghost is at an end: at the steps in ends once,
+// then from loopStart on at the steps in loop and every period steps
+// after those
+type ghost struct {
+    ends []int
+    loopStart int
+    period int
+    loop []int
+}
+
+// at reports whether the ghost is at an end after steps
+func (g ghost) at(steps int) bool {
+    within := g.ends
+    if steps >= g.loopStart {
+        steps, within = g.loopStart + (steps - g.loopStart) % g.period, g.loop
+    }
+    for _, end := range within {
+        if end == steps {
+            return true
+        }
+    }
+    return false
+}
+
+var errNeverMeet = errors.New("the ghosts never meet")
+
+// followGhost walks from a start until the ghost is at an end and then
+// on until it repeats where it is in the instructions and the map. A
+// ghost never at an end fails with errNeverMeet.
+func followGhost(ctx context.Context, instructions []rune, coordinates map[string]Pair, from string) (ghost, error) {
+    type state struct {
+        key string
+        instruction int
+    }
+    // the states since the first end
+    seen := map[state]int{}
+    g := ghost{}
+    ends := []int{}
+    key := from
+    for steps := 0; ; steps++ {
+        if steps % puzzle.CHECK_EVERY == 0 {
+            if err := puzzle.Interrupt(ctx, 2, "%d steps from %s", steps, from); err != nil {
+                return ghost{}, err
+            }
+        }
+        // past every node at every instruction without an end the ghost
+        // only goes round
+        if len(ends) == 0 && steps >= len(coordinates) * len(instructions) {
+            return ghost{}, errNeverMeet
+        }
+        instructionIndex := steps % len(instructions)
+        if endsWith(key, 'Z') || len(ends) > 0 {
+            current := state{key, instructionIndex}
+            if loopStart, ok := seen[current]; ok {
+                g.loopStart, g.period = loopStart, steps - loopStart
+                break
+            }
+            seen[current] = steps
+            if endsWith(key, 'Z') {
+                ends = append(ends, steps)
+            }
+        }
+        pair := coordinates[key]
+        if instructions[instructionIndex] == 'L' {
+            key = pair.Left
+        } else {
+            key = pair.Right
+        }
+    }
+    for _, end := range ends {
+        if end < g.loopStart {
+            g.ends = append(g.ends, end)
+        } else {
+            g.loop = append(g.loop, end)
+        }
+    }
+    return g, nil
+}
+
+// MAX_COMBINATIONS bounds the ways to pick an end of each ghost's loop
+const MAX_COMBINATIONS = 1 << 16
+
+// meet returns the steps after which all ghosts are at an end at once
+func meet(ghosts []ghost) (int, error) {
+    // before some ghost is in its loop it is at one of its first ends
+    candidates := []int{}
+    latestLoop := 0
+    combinations := 1
+    for _, g := range ghosts {
+        candidates = append(candidates, g.ends...)
+        latestLoop = max(latestLoop, g.loopStart)
+        combinations *= len(g.loop)
+        if combinations > MAX_COMBINATIONS {
+            return 0, fmt.Errorf("more than %d ways for the ghosts to meet", MAX_COMBINATIONS)
+        }
+    }
+    sort.Ints(candidates)
+    for _, steps := range candidates {
+        all := true
+        for _, g := range ghosts {
+            all = all && g.at(steps)
+        }
+        if all {
+            return steps, nil
+        }
+    }
+    // else all are in their loops, meeting where each is at one of its
+    // ends, every lcm of the periods steps
+    best := -1
+    picks := make([]int, len(ghosts))
+    remainders := make([]int64, len(ghosts))
+    moduli := make([]int64, len(ghosts))
+    for i := 0; i < combinations; i++ {
+        rest := i
+        for k, g := range ghosts {
+            picks[k] = g.loop[rest % len(g.loop)]
+            rest /= len(g.loop)
+            remainders[k], moduli[k] = int64(picks[k] % g.period), int64(g.period)
+        }
+        x, m, err := mathx.CRT(remainders, moduli)
+        if errors.Is(err, mathx.ErrNoSolution) {
+            continue
+        }
+        if err != nil {
+            return 0, fmt.Errorf("combining ends %v of loops %v: %w", picks, moduli, err)
+        }
+        if x < int64(latestLoop) {
+            x += (int64(latestLoop) - x + m - 1) / m * m
+        }
+        if best < 0 || int(x) < best {
+            best = int(x)
+        }
+    }
+    if best < 0 {
+        return 0, errNeverMeet
+    }
+    return best, nil
+}
+
+func solvePt2(ctx context.Context, instructions []rune, coordinates map[string]Pair) (int, error) {
+    ghosts := []ghost{}
+    for key := range coordinates {
+        if endsWith(key, 'A') {
+            g, err := followGhost(ctx, instructions, coordinates, key)
+            if err != nil {
+                return 0, err
+            }
+            puzzle.Logger(ctx).Debug("ghost", "start", key, "ends", g.ends, "loop", g.loop, "from", g.loopStart, "period", g.period)
+            ghosts = append(ghosts, g)
+        }
+    }
+    if len(ghosts) == 0 {
+        return 0, nil
+    }
+    return meet(ghosts)
+}
+
+func readMap(r io.Reader) ([]rune, map[string]Pair, error) {
+    scanner := puzzle.NewScanner(r)
+
+    index := 0
+    instructions := []rune{}
+    coordinates := map[string]Pair{}
+    // the targets of every line, checked once all nodes are known
+    type target struct {
+        puzzle.Field
+        line int
+    }
+    targets := []target{}
+    for scanner.Scan() {
+        line := scanner.Text()
+        if index == 0 {
+            instructions = []rune(line)
+            if len(instructions) == 0 {
+                return nil, nil, scanner.Errorf(1, "", "missing instructions")
+            }
+            for i, instruction := range instructions {
+                if instruction != 'L' && instruction != 'R' {
+                    return nil, nil, scanner.Errorf(i + 1, string(instruction), "unknown instruction")
+                }
+            }
+        }
+        if index > 1 {
+            key, pair, fields, err := parseLine(line)
+            if err != nil {
+                return nil, nil, puzzle.AtLine(err, scanner.Line)
+            }
+            coordinates[key] = pair
+            for _, field := range fields {
+                targets = append(targets, target{field, scanner.Line})
+            }
+        }
+        index++
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, nil, err
+    }
+    for _, t := range targets {
+        if _, ok := coordinates[t.Text]; !ok {
+            return nil, nil, puzzle.AtLine(t.Errorf("unknown node"), t.line)
+        }
+    }
+    return instructions, coordinates, nil
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    instructions, coordinates, err := readMap(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    puzzle.Logger(ctx).Debug("map", "instructions", len(instructions), "nodes", len(coordinates))
+    result := puzzle.Result{}
+    result.Part1, err = solvePt1(ctx, instructions, coordinates)
+    if err != nil {
+        return result, err
+    }
+    result.Part2, err = solvePt2(ctx, instructions, coordinates)
+    return result, err
+}
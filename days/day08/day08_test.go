@@ -0,0 +1,163 @@
+package day08
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/prop"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day08_part2.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, _, err := readMap(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    instructions, coordinates, err := readMap(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if _, err := solvePt1(context.Background(), instructions, coordinates); err != nil {
+            b.Fatal(err)
+        }
+        if _, err := solvePt2(context.Background(), instructions, coordinates); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func FuzzReadMap(f *testing.F) {
+    f.Add(readExample(f))
+    f.Fuzz(func(t *testing.T, input []byte) {
+        readMap(bytes.NewReader(input))
+    })
+}
+
+// referenceSteps walks all ghosts at once until every one is at an end,
+// it gives up after limit steps
+func referenceSteps(instructions []rune, coordinates map[string]Pair, starts []string, limit int) (int, bool) {
+    ghosts := append([]string{}, starts...)
+    for steps := 0; steps <= limit; steps++ {
+        done := true
+        for _, ghost := range ghosts {
+            done = done && endsWith(ghost, 'Z')
+        }
+        if done {
+            return steps, true
+        }
+        for i, ghost := range ghosts {
+            if instructions[steps % len(instructions)] == 'L' {
+                ghosts[i] = coordinates[ghost].Left
+            } else {
+                ghosts[i] = coordinates[ghost].Right
+            }
+        }
+    }
+    return 0, false
+}
+
+func TestReferenceSteps(t *testing.T) {
+    prop.Check(t, func(rng *rand.Rand) error {
+        lengths := make([]int, 1 + rng.Intn(3))
+        for i := range lengths {
+            lengths[i] = 1 + rng.Intn(9)
+        }
+        input := genNetwork(rng, 1 + rng.Intn(5), lengths)
+        instructions, coordinates, err := readMap(bytes.NewReader(input))
+        if err != nil {
+            return err
+        }
+        starts := []string{}
+        for key := range coordinates {
+            if endsWith(key, 'A') {
+                starts = append(starts, key)
+            }
+        }
+        want, ok := referenceSteps(instructions, coordinates, starts, 1000000)
+        if !ok {
+            return fmt.Errorf("ghosts never meet\n%s", input)
+        }
+        got, err := solvePt2(context.Background(), instructions, coordinates)
+        if err != nil {
+            return err
+        }
+        if got != want {
+            return fmt.Errorf("ghosts meet after %d steps, want %d\n%s", got, want, input)
+        }
+        want, _ = referenceSteps(instructions, coordinates, []string{"AAA"}, 1000000)
+        if got, err := solvePt1(context.Background(), instructions, coordinates); err != nil || got != want {
+            return fmt.Errorf("AAA reaches ZZZ after %d steps (%v), want %d\n%s", got, err, want, input)
+        }
+        return nil
+    })
+}
+
+// genAnyNetwork returns a few nodes linked at random, up to three of
+// them starts and any of the others ends, so the loops need not be clean
+func genAnyNetwork(rng *rand.Rand) []byte {
+    var b bytes.Buffer
+    for i := 1 + rng.Intn(3); i > 0; i-- {
+        b.WriteByte("LR"[rng.Intn(2)])
+    }
+    b.WriteString("\n\n")
+    names := make([]string, 2 + rng.Intn(6))
+    for i := range names {
+        last := "ZZB"[rng.Intn(3)]
+        if i < 3 && rng.Intn(2) == 0 {
+            last = 'A'
+        }
+        names[i] = fmt.Sprintf("%02d%c", i, last)
+    }
+    for _, name := range names {
+        fmt.Fprintf(&b, "%s = (%s, %s)\n", name, names[rng.Intn(len(names))], names[rng.Intn(len(names))])
+    }
+    return b.Bytes()
+}
+
+func TestReferenceStepsAnyNetwork(t *testing.T) {
+    prop.Check(t, func(rng *rand.Rand) error {
+        input := genAnyNetwork(rng)
+        instructions, coordinates, err := readMap(bytes.NewReader(input))
+        if err != nil {
+            return err
+        }
+        starts := []string{}
+        for key := range coordinates {
+            if endsWith(key, 'A') {
+                starts = append(starts, key)
+            }
+        }
+        // the loops are at most 21 steps, any meeting comes early
+        want, ok := referenceSteps(instructions, coordinates, starts, 10000)
+        got, err := solvePt2(context.Background(), instructions, coordinates)
+        if !ok {
+            if !errors.Is(err, errNeverMeet) {
+                return fmt.Errorf("ghosts meet after %d steps (%v), want never\n%s", got, err, input)
+            }
+            return nil
+        }
+        if err != nil || got != want {
+            return fmt.Errorf("ghosts meet after %d steps (%v), want %d\n%s", got, err, want, input)
+        }
+        return nil
+    })
+}
@@ -0,0 +1,89 @@
+package day08
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// GEN_LETTERS names the nodes, without A and Z which mark starts and ends
+const GEN_LETTERS = "BCDEFGHIJKLMNOPQRSTUVWXY"
+
+var genPrimes = []int{3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47}
+
+// genName returns the name of the i-th node, at least length letters long
+func genName(i int, length int) string {
+    name := []byte{}
+    for len(name) < length || i > 0 {
+        name = append(name, GEN_LETTERS[i % len(GEN_LETTERS)])
+        i /= len(GEN_LETTERS)
+    }
+    return string(name)
+}
+
+// genNetwork returns the instructions and a network of ghosts, one
+// for each length. As in the puzzle every ghost walks a loop through its
+// end. The nodes of a loop are paired, both of a pair lead to the next
+// pair whatever the instruction, so a ghost reaches its end after length
+// steps and then every length steps again.
+func genNetwork(rng *rand.Rand, instructions int, lengths []int) []byte {
+    var b bytes.Buffer
+    for i := 0; i < instructions; i++ {
+        b.WriteByte("LR"[rng.Intn(2)])
+    }
+    b.WriteString("\n\n")
+
+    lines := []string{}
+    node := func(key string, left string, right string) {
+        if rng.Intn(2) == 0 {
+            left, right = right, left
+        }
+        lines = append(lines, fmt.Sprintf("%s = (%s, %s)", key, left, right))
+    }
+    next := 0
+    for ghost, length := range lengths {
+        start, end := "AAA", "ZZZ"
+        if ghost > 0 {
+            start, end = genName(ghost, 2) + "A", genName(ghost, 2) + "Z"
+        }
+        // the pairs between end and end
+        pairs := make([][2]string, length - 1)
+        for i := range pairs {
+            pairs[i] = [2]string{genName(next, 3), genName(next + 1, 3)}
+            next += 2
+        }
+        if len(pairs) == 0 {
+            node(start, end, end)
+            node(end, end, end)
+            continue
+        }
+        node(start, pairs[0][0], pairs[0][1])
+        for i := 0; i + 1 < len(pairs); i++ {
+            node(pairs[i][0], pairs[i + 1][0], pairs[i + 1][1])
+            node(pairs[i][1], pairs[i + 1][0], pairs[i + 1][1])
+        }
+        last := pairs[len(pairs) - 1]
+        node(last[0], end, end)
+        node(last[1], end, end)
+        node(end, pairs[0][0], pairs[0][1])
+    }
+    rng.Shuffle(len(lines), func(i, j int) {
+        lines[i], lines[j] = lines[j], lines[i]
+    })
+    for _, line := range lines {
+        b.WriteString(line)
+        b.WriteByte('\n')
+    }
+    return b.Bytes()
+}
+
+// Generate returns size instructions and the network of two to six
+// ghosts, each walking a loop of size times a prime steps.
+func Generate(rng *rand.Rand, size int) []byte {
+    size = max(size, 1)
+    lengths := []int{}
+    for _, p := range rng.Perm(len(genPrimes))[:2 + rng.Intn(5)] {
+        lengths = append(lengths, size * genPrimes[p])
+    }
+    return genNetwork(rng, size, lengths)
+}
@@ -0,0 +1,83 @@
+package day11
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"stefanvonderkrone/adventOfCode2023/geom"
+	"stefanvonderkrone/adventOfCode2023/grid"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+const GALAXY = '#'
+
+func solve(universe []geom.Point) int {
+    universeSize := len(universe)
+    sum := 0
+    for i := 0; i < universeSize; i++ {
+        g1 := universe[i]
+        for k := i+1; k < universeSize; k++ {
+            g2 := universe[k]
+            sum += geom.Manhattan(g1, g2)
+        }
+    }
+    return sum
+}
+
+const EXPAND_DELTA = 1000000
+
+// expansionDeltas returns how far each row or column moves once every
+// empty one before it has grown to expandDelta
+func expansionDeltas(lines [][]byte, expandDelta int) []int {
+    deltas := make([]int, len(lines))
+    delta := 0
+    for i, line := range lines {
+        isEmpty := true
+        for _, char := range line {
+            if char == GALAXY {
+                isEmpty = false
+                break
+            }
+        }
+        if isEmpty {
+            delta += expandDelta - 1
+        }
+        deltas[i] = delta
+    }
+    return deltas
+}
+
+func expandUniverse(log *slog.Logger, g *grid.Grid, expandDelta int) []geom.Point {
+    rows := make([][]byte, g.Height)
+    for y := range rows {
+        rows[y] = g.Row(y)
+    }
+    columns := make([][]byte, g.Width)
+    for x := range columns {
+        columns[x] = g.Column(x)
+    }
+    rowDeltas := expansionDeltas(rows, expandDelta)
+    columnDeltas := expansionDeltas(columns, expandDelta)
+
+    universe := []geom.Point{}
+    for _, p := range g.FindAll(GALAXY) {
+        universe = append(universe, geom.Point{X: p.X + columnDeltas[p.X], Y: p.Y + rowDeltas[p.Y]})
+    }
+
+    log.Debug("universe", "delta", expandDelta, "galaxies", len(universe), "rowDeltas", rowDeltas, "columnDeltas", columnDeltas)
+
+    return universe
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    g, err := grid.Read(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    log := puzzle.Logger(ctx)
+    return puzzle.Result{
+        Part1: solve(expandUniverse(log, g, 2)),
+        Part2: solve(expandUniverse(log, g, EXPAND_DELTA)),
+    }, nil
+}
@@ -0,0 +1,28 @@
+package day11
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+// Generate returns a size x size image with a few empty rows and columns
+// between the galaxies.
+func Generate(rng *rand.Rand, size int) []byte {
+    emptyColumns := map[int]bool{}
+    for x := 0; x < size; x++ {
+        emptyColumns[x] = rng.Intn(10) == 0
+    }
+    var b bytes.Buffer
+    for y := 0; y < size; y++ {
+        emptyRow := rng.Intn(10) == 0
+        for x := 0; x < size; x++ {
+            if !emptyRow && !emptyColumns[x] && rng.Intn(40) == 0 {
+                b.WriteByte(GALAXY)
+            } else {
+                b.WriteByte('.')
+            }
+        }
+        b.WriteByte('\n')
+    }
+    return b.Bytes()
+}
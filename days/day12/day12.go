@@ -0,0 +1,184 @@
+package day12
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func readLine(line string) (string, []int, error) {
+    i := 0
+    for i < len(line) && line[i] != ' ' {
+        if !strings.ContainsRune(".#?", rune(line[i])) {
+            return "", nil, puzzle.Errorf(i + 1, line[i:i + 1], "unknown spring")
+        }
+        i++
+    }
+    if i == len(line) {
+        return "", nil, puzzle.Errorf(i + 1, "", "missing group sizes")
+    }
+    order := line[0:i]
+    i++
+    k := i
+    amounts := []int{}
+    for i <= len(line) {
+        if i == len(line) || line[i] == ',' {
+            field := puzzle.Field{Text: line[k:i], Column: k + 1}
+            amount, err := field.Atoi()
+            if err != nil {
+                return "", nil, err
+            }
+            if amount <= 0 {
+                return "", nil, field.Errorf("invalid group size")
+            }
+            amounts = append(amounts, amount)
+            k = i + 1
+        }
+        i++
+    }
+    return order, amounts, nil
+}
+
+// counter holds the counts computed so far. Every Solve uses its own, so
+// that days can be solved concurrently. Once ctx is done every count is
+// 0 and the error is kept in err.
+type counter struct {
+    ctx context.Context
+    cache map[string]int
+    calls int
+    err error
+}
+
+func newCounter(ctx context.Context) *counter {
+    return &counter{ctx: ctx, cache: map[string]int{}}
+}
+
+// inspired by https://youtu.be/g3Ms5e7Jdqo?si=V-BZWDgR5X0fZiVg
+
+func (c *counter) count(cfg string, nums []int) int {
+    c.calls++
+    if c.calls % puzzle.CHECK_EVERY == 0 && c.err == nil {
+        c.err = c.ctx.Err()
+    }
+    if c.err != nil {
+        return 0
+    }
+
+    if cfg == "" {
+        if len(nums) == 0 {
+            return 1
+        }
+        return 0
+    }
+
+    if len(nums) == 0 {
+        if strings.Contains(cfg, "#") {
+            return 0
+        }
+        return 1
+    }
+
+    key := strings.Join([]string{cfg, fmt.Sprintf("%+v", nums)}, " ")
+
+    if r, ok := c.cache[key]; ok {
+        return r
+    }
+
+    result := 0
+
+    setCache := func() {
+        c.cache[key] = result
+    }
+
+    defer setCache()
+
+    if cfg[0] == '.' || cfg[0] == '?' {
+        result += c.count(cfg[1:], nums)
+    }
+
+    if cfg[0] == '#' || cfg[0] == '?' {
+        if nums[0] > len(cfg) {
+            return result
+        }
+        if strings.Contains(cfg[:nums[0]], ".") {
+            return result
+        }
+
+        if nums[0] == len(cfg) || cfg[nums[0]] != '#' {
+            startIndex := nums[0] + 1
+            if startIndex > len(cfg) {
+                startIndex = len(cfg)
+            }
+            result += c.count(cfg[startIndex:], nums[1:])
+        }
+    }
+
+    return result
+}
+
+func unfold(cfg string, nums []int) (string, []int) {
+    cfg = strings.Join([]string{cfg, cfg, cfg, cfg, cfg}, "?")
+    tmpNums := nums
+    nums = append(nums, tmpNums...)
+    nums = append(nums, tmpNums...)
+    nums = append(nums, tmpNums...)
+    nums = append(nums, tmpNums...)
+    return cfg, nums
+}
+
+// readRecords returns the springs and group sizes of every line
+func readRecords(r io.Reader) ([]string, [][]int, error) {
+    scanner := puzzle.NewScanner(r)
+
+    cfgs := []string{}
+    nums := [][]int{}
+    for scanner.Scan() {
+        line := scanner.Text()
+        cfg, n, err := readLine(line)
+        if err != nil {
+            return nil, nil, puzzle.AtLine(err, scanner.Line)
+        }
+        cfgs = append(cfgs, cfg)
+        nums = append(nums, n)
+    }
+    return cfgs, nums, scanner.Err()
+}
+
+// countAll sums the arrangements of every record, unfolded for part 2
+func (c *counter) countAll(cfgs []string, nums [][]int, part int) (int, error) {
+    sum := 0
+    for i := range cfgs {
+        if c.err == nil {
+            c.err = c.ctx.Err()
+        }
+        cfg, n := cfgs[i], nums[i]
+        if part == 2 {
+            cfg, n = unfold(cfg, n)
+        }
+        arrangements := c.count(cfg, n)
+        puzzle.Logger(c.ctx).Debug("record", "part", part, "springs", cfg, "groups", n, "arrangements", arrangements)
+        sum += arrangements
+        if c.err != nil {
+            return 0, &puzzle.Interrupted{Part: part, Progress: fmt.Sprintf("%d of %d records", i, len(cfgs)), Err: c.err}
+        }
+    }
+    return sum, nil
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    cfgs, nums, err := readRecords(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    c := newCounter(ctx)
+    result := puzzle.Result{}
+    result.Part1, err = c.countAll(cfgs, nums, 1)
+    if err != nil {
+        return result, err
+    }
+    result.Part2, err = c.countAll(cfgs, nums, 2)
+    return result, err
+}
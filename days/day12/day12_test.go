@@ -0,0 +1,138 @@
+package day12
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/prop"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day12.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, _, err := readRecords(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    cfgs, nums, err := readRecords(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        c := newCounter(context.Background())
+        for part := 1; part <= 2; part++ {
+            if _, err := c.countAll(cfgs, nums, part); err != nil {
+                b.Fatal(err)
+            }
+        }
+    }
+}
+
+func FuzzReadLine(f *testing.F) {
+    for _, line := range strings.Split(string(readExample(f)), "\n") {
+        f.Add(line)
+    }
+    f.Fuzz(func(t *testing.T, line string) {
+        springs, groups, err := readLine(line)
+        if err != nil {
+            return
+        }
+        if strings.Trim(springs, ".#?") != "" {
+            t.Errorf("readLine(%q) returned springs %q", line, springs)
+        }
+        for _, size := range groups {
+            if size <= 0 {
+                t.Errorf("readLine(%q) returned group size %d", line, size)
+            }
+        }
+    })
+}
+
+// referenceCount tries every way to fill in the unknown springs
+func referenceCount(springs string, groups []int) int {
+    unknown := strings.Count(springs, "?")
+    count := 0
+    filled := []byte(springs)
+    for bits := 0; bits < 1 << unknown; bits++ {
+        k := 0
+        for i := range filled {
+            if springs[i] == '?' {
+                filled[i] = ".#"[bits >> k & 1]
+                k++
+            }
+        }
+        if reflect.DeepEqual(referenceGroups(filled), groups) {
+            count++
+        }
+    }
+    return count
+}
+
+// referenceGroups returns the sizes of the groups of damaged springs
+func referenceGroups(springs []byte) []int {
+    groups := []int{}
+    size := 0
+    for _, spring := range append(springs, '.') {
+        if spring == '#' {
+            size++
+        } else if size > 0 {
+            groups = append(groups, size)
+            size = 0
+        }
+    }
+    return groups
+}
+
+func TestReferenceCount(t *testing.T) {
+    prop.Check(t, func(rng *rand.Rand) error {
+        springs, groups, err := readLine(strings.TrimSpace(string(Generate(rng, 1))))
+        if err != nil {
+            return err
+        }
+        c := newCounter(context.Background())
+        if got, want := c.count(springs, groups), referenceCount(springs, groups); got != want {
+            return fmt.Errorf("%s %v: %d arrangements, want %d", springs, groups, got, want)
+        }
+        // unfolded only short records have few enough to try, these
+        // need not have any arrangement
+        short := []byte{}
+        for i := 1 + rng.Intn(4); i > 0; i-- {
+            short = append(short, ".#?"[rng.Intn(3)])
+        }
+        groups = []int{}
+        for i := 1 + rng.Intn(2); i > 0; i-- {
+            groups = append(groups, 1 + rng.Intn(2))
+        }
+        springs = string(short)
+        for strings.Count(springs, "?") > 1 {
+            springs = strings.Replace(springs, "?", ".", 1)
+        }
+        for _, unfolded := range []bool{false, true} {
+            if unfolded {
+                springs, groups = unfold(springs, groups)
+            }
+            if got, want := c.count(springs, groups), referenceCount(springs, groups); got != want {
+                return fmt.Errorf("%s %v: %d arrangements, want %d", springs, groups, got, want)
+            }
+        }
+        return nil
+    })
+}
@@ -0,0 +1,39 @@
+package day12
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Generate returns size records of up to 20 springs. Each is a valid
+// arrangement of its groups with about half of the springs turned
+// unknown, so there is at least one arrangement.
+func Generate(rng *rand.Rand, size int) []byte {
+    var b bytes.Buffer
+    for i := 0; i < size; i++ {
+        springs := strings.Repeat(".", rng.Intn(3))
+        groups := []string{}
+        for g := 1 + rng.Intn(5); g > 0; g-- {
+            group := 1 + rng.Intn(5)
+            if len(springs) + group + 3 > 20 {
+                break
+            }
+            springs += strings.Repeat("#", group) + strings.Repeat(".", 1 + rng.Intn(3))
+            groups = append(groups, strconv.Itoa(group))
+        }
+        if len(groups) == 0 {
+            springs, groups = "#", []string{"1"}
+        }
+        record := []byte(springs)
+        for k := range record {
+            if rng.Intn(2) == 0 {
+                record[k] = '?'
+            }
+        }
+        fmt.Fprintf(&b, "%s %s\n", record, strings.Join(groups, ","))
+    }
+    return b.Bytes()
+}
@@ -0,0 +1,110 @@
+package day14
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"stefanvonderkrone/adventOfCode2023/grid"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func fallThrough(row []byte) []byte {
+    for x, byte := range row {
+        if byte == 'O' && x > 0 {
+            xx := x
+            for xx > 0 {
+                xx--
+                if row[xx] != '.' {
+                    xx++
+                    break
+                }
+            }
+            row[x] = '.'
+            row[xx] = 'O'
+        }
+    }
+    return row
+}
+
+func tiltNorth(platform *grid.Grid) *grid.Grid {
+    for x := 0; x < platform.Width; x++ {
+        platform.SetColumn(x, fallThrough(platform.Column(x)))
+    }
+    return platform
+}
+
+// tiltCycle tilts north, west, south and east. Rotating the platform
+// clockwise after each tilt brings the next direction to the north.
+func tiltCycle(platform *grid.Grid) *grid.Grid {
+    for i := 0; i < 4; i++ {
+        platform = tiltNorth(platform).RotateClockwise()
+    }
+    return platform
+}
+
+func calc(platform *grid.Grid) int {
+    lineNo := platform.Height
+    sum := 0
+    for y := 0; y < platform.Height; y++ {
+        for _, byte := range platform.Row(y) {
+            if byte == 'O' {
+                sum += lineNo
+            }
+        }
+        lineNo--
+    }
+    return sum
+}
+
+func solvePt1(log *slog.Logger, platform *grid.Grid) int {
+    debug := log.Enabled(context.Background(), slog.LevelDebug)
+    if debug {
+        log.Debug("platform", "state", platform.Pretty())
+    }
+    platform = tiltNorth(platform)
+    if debug {
+        log.Debug("tilted", "state", platform.Pretty())
+    }
+    sum := calc(platform)
+    return sum
+}
+
+func solvePt2(ctx context.Context, platform *grid.Grid) (int, error) {
+    cycles := 1000000000
+    // the platform settles into a loop, once a state repeats the
+    // remaining full loops can be skipped
+    seen := map[string]int{}
+    for i := 0; i < cycles; i++ {
+        if err := puzzle.Interrupt(ctx, 2, "%d of %d cycles", i, cycles); err != nil {
+            return 0, err
+        }
+        if seen != nil {
+            key := platform.String()
+            if j, ok := seen[key]; ok {
+                period := i - j
+                puzzle.Logger(ctx).Debug("loop", "start", j, "period", period)
+                i += (cycles - i) / period * period
+                seen = nil
+                if i == cycles {
+                    break
+                }
+            } else {
+                seen[key] = i
+            }
+        }
+        platform = tiltCycle(platform)
+    }
+    sum := calc(platform)
+    return sum, nil
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    platform, err := grid.Read(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    result := puzzle.Result{Part1: solvePt1(puzzle.Logger(ctx), platform.Clone())}
+    result.Part2, err = solvePt2(ctx, platform.Clone())
+    return result, err
+}
@@ -0,0 +1,42 @@
+package day14
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/grid"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day14.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, err := grid.Read(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    g, err := grid.Read(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        solvePt1(puzzle.Logger(context.Background()), g.Clone())
+        if _, err := solvePt2(context.Background(), g.Clone()); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
@@ -0,0 +1,25 @@
+package day14
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+// Generate returns a size x size platform of round and cube-shaped rocks.
+func Generate(rng *rand.Rand, size int) []byte {
+    var b bytes.Buffer
+    for y := 0; y < size; y++ {
+        for x := 0; x < size; x++ {
+            switch n := rng.Intn(20); {
+            case n < 4:
+                b.WriteByte('O')
+            case n < 7:
+                b.WriteByte('#')
+            default:
+                b.WriteByte('.')
+            }
+        }
+        b.WriteByte('\n')
+    }
+    return b.Bytes()
+}
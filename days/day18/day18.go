@@ -0,0 +1,109 @@
+package day18
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"stefanvonderkrone/adventOfCode2023/geom"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+var directions = map[string]geom.Point{
+    "U": geom.Up,
+    "D": geom.Down,
+    "L": geom.Left,
+    "R": geom.Right,
+}
+
+const directionIndices = "RDLU"
+
+func parseColor(field puzzle.Field) (int, geom.Point, error) {
+    color := field.Text
+    if len(color) != 9 || !strings.HasPrefix(color, "(#") || !strings.HasSuffix(color, ")") {
+        return 0, geom.Point{}, field.Errorf("expected '(#rrggbb)', found")
+    }
+    x := color[2:]
+    stepsHex := x[0:5]
+    dir := x[5:6]
+    s, err := strconv.ParseInt(stepsHex, 16, 64)
+    if err != nil {
+        return 0, geom.Point{}, puzzle.Errorf(field.Column + 2, stepsHex, "invalid hex number")
+    }
+    dirIndex, err := strconv.Atoi(dir)
+    if err != nil || dirIndex >= len(directionIndices) {
+        return 0, geom.Point{}, puzzle.Errorf(field.Column + 7, dir, "unknown direction")
+    }
+    return int(s), directions[directionIndices[dirIndex:dirIndex + 1]], nil
+}
+
+func scanPoints(lines []string, fromColor bool) ([]geom.Point, error) {
+    points := []geom.Point{geom.Point{}}
+    for i, line := range lines {
+        fields := puzzle.SplitFields(line, " ")
+        if len(fields) != 3 {
+            return nil, puzzle.AtLine(puzzle.Errorf(1, line, "expected '<direction> <steps> <color>', found"), i + 1)
+        }
+        directionPoint, ok := directions[fields[0].Text]
+        if !ok {
+            return nil, puzzle.AtLine(fields[0].Errorf("unknown direction"), i + 1)
+        }
+        steps, err := fields[1].Atoi()
+        if err != nil {
+            return nil, puzzle.AtLine(err, i + 1)
+        }
+        if fromColor {
+            // pt2
+            steps, directionPoint, err = parseColor(fields[2])
+            if err != nil {
+                return nil, puzzle.AtLine(err, i + 1)
+            }
+        }
+        lastPoint := points[len(points) - 1]
+        points = append(points, lastPoint.Add(directionPoint.Scale(steps)))
+    }
+    return points, nil
+}
+
+// lagoonSize counts the trench itself and the cubic meters it encloses
+func lagoonSize(points []geom.Point) int {
+    return geom.InteriorPoints(points) + geom.Perimeter(points)
+}
+
+// readPlans returns the corners of the lagoon as given by the directions
+// and as hidden in the colors
+func readPlans(r io.Reader) ([]geom.Point, []geom.Point, error) {
+    scanner := bufio.NewScanner(r)
+
+    lines := []string{}
+    for scanner.Scan() {
+        lines = append(lines, scanner.Text())
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, nil, err
+    }
+    points, err := scanPoints(lines, false)
+    if err != nil {
+        return nil, nil, err
+    }
+    colorPoints, err := scanPoints(lines, true)
+    if err != nil {
+        return nil, nil, err
+    }
+    return points, colorPoints, nil
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    points, colorPoints, err := readPlans(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    log := puzzle.Logger(ctx)
+    log.Debug("plans", "corners", len(points), "colorCorners", len(colorPoints))
+    return puzzle.Result{
+        Part1: lagoonSize(points),
+        Part2: lagoonSize(colorPoints),
+    }, nil
+}
@@ -0,0 +1,113 @@
+package day18
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/geom"
+	"stefanvonderkrone/adventOfCode2023/prop"
+)
+
+func readExample(tb testing.TB) []byte {
+    input, err := os.ReadFile("../testdata/day18.txt")
+    if err != nil {
+        tb.Fatal(err)
+    }
+    return input
+}
+
+func BenchmarkParse(b *testing.B) {
+    input := readExample(b)
+    for i := 0; i < b.N; i++ {
+        if _, _, err := readPlans(bytes.NewReader(input)); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSolve(b *testing.B) {
+    points, colorPoints, err := readPlans(bytes.NewReader(readExample(b)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        lagoonSize(points)
+        lagoonSize(colorPoints)
+    }
+}
+
+func FuzzReadPlans(f *testing.F) {
+    f.Add(readExample(f))
+    f.Fuzz(func(t *testing.T, input []byte) {
+        readPlans(bytes.NewReader(input))
+    })
+}
+
+// referenceSize digs the trench cell by cell and counts every cell the
+// ground outside of it cannot reach
+func referenceSize(corners []geom.Point) int {
+    trench := map[geom.Point]bool{}
+    lo, hi := corners[0], corners[0]
+    for i := 0; i + 1 < len(corners); i++ {
+        from, to := corners[i], corners[i + 1]
+        step := geom.Point{X: sign(to.X - from.X), Y: sign(to.Y - from.Y)}
+        for p := from; p != to; p = p.Add(step) {
+            trench[p] = true
+        }
+        lo = geom.Point{X: min(lo.X, to.X), Y: min(lo.Y, to.Y)}
+        hi = geom.Point{X: max(hi.X, to.X), Y: max(hi.Y, to.Y)}
+    }
+    // a border of ground around the lagoon connects the outside
+    lo, hi = lo.Sub(geom.Point{X: 1, Y: 1}), hi.Add(geom.Point{X: 1, Y: 1})
+    outside := map[geom.Point]bool{lo: true}
+    todo := []geom.Point{lo}
+    for len(todo) > 0 {
+        p := todo[len(todo) - 1]
+        todo = todo[:len(todo) - 1]
+        for _, d := range directions {
+            q := p.Add(d)
+            if q.X < lo.X || q.X > hi.X || q.Y < lo.Y || q.Y > hi.Y || trench[q] || outside[q] {
+                continue
+            }
+            outside[q] = true
+            todo = append(todo, q)
+        }
+    }
+    return (hi.X - lo.X + 1) * (hi.Y - lo.Y + 1) - len(outside)
+}
+
+func sign(n int) int {
+    switch {
+    case n < 0:
+        return -1
+    case n > 0:
+        return 1
+    }
+    return 0
+}
+
+func TestReferenceSize(t *testing.T) {
+    points, _, err := readPlans(bytes.NewReader(readExample(t)))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got := referenceSize(points); got != 62 {
+        t.Fatalf("reference size of the example = %d, want 62", got)
+    }
+    prop.Check(t, func(rng *rand.Rand) error {
+        input := Generate(rng, 1 + rng.Intn(8))
+        // the colors are too far apart to dig cell by cell
+        points, _, err := readPlans(bytes.NewReader(input))
+        if err != nil {
+            return err
+        }
+        if got, want := lagoonSize(points), referenceSize(points); got != want {
+            return fmt.Errorf("lagoon of %d, want %d\n%s", got, want, input)
+        }
+        return nil
+    })
+}
@@ -0,0 +1,65 @@
+package day18
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// GEN_MAX_STEPS is the most steps 5 hex digits can encode
+const GEN_MAX_STEPS = 0xfffff
+
+type genMove struct {
+    Direction int
+    Steps int
+}
+
+// genLagoon returns the outline of a histogram of columns bars, turned
+// by a random multiple of 90 degrees, so it never crosses itself. The
+// bars are at most maxWidth wide and maxHeight high, neighbours differ
+// in height, so maxHeight has to be 2 or more.
+func genLagoon(rng *rand.Rand, columns int, maxWidth int, maxHeight int) []genMove {
+    // indices into directionIndices, RDLU
+    const R, D, L, U = 0, 1, 2, 3
+    height := 1 + rng.Intn(maxHeight)
+    width := 1 + rng.Intn(maxWidth)
+    moves := []genMove{{U, height}, {R, width}}
+    total := width
+    for i := 1; i < columns; i++ {
+        next := height
+        for next == height {
+            next = 1 + rng.Intn(maxHeight)
+        }
+        if next > height {
+            moves = append(moves, genMove{U, next - height})
+        } else {
+            moves = append(moves, genMove{D, height - next})
+        }
+        height = next
+        width = 1 + rng.Intn(maxWidth)
+        moves = append(moves, genMove{R, width})
+        total += width
+    }
+    moves = append(moves, genMove{D, height}, genMove{L, total})
+    turn := rng.Intn(4)
+    for i := range moves {
+        moves[i].Direction = (moves[i].Direction + turn) % 4
+    }
+    return moves
+}
+
+// Generate returns a dig plan of 2 * size + 2 lines. The directions and
+// the colors each describe a lagoon of size columns, the colors one
+// with up to GEN_MAX_STEPS steps in each direction.
+func Generate(rng *rand.Rand, size int) []byte {
+    // each column is at least one step wide
+    size = min(max(size, 1), GEN_MAX_STEPS)
+    plan := genLagoon(rng, size, 10, 10)
+    colors := genLagoon(rng, size, GEN_MAX_STEPS / size, GEN_MAX_STEPS)
+    var b bytes.Buffer
+    for i, move := range plan {
+        color := colors[i]
+        fmt.Fprintf(&b, "%c %d (#%05x%d)\n", directionIndices[move.Direction], move.Steps, color.Steps, color.Direction)
+    }
+    return b.Bytes()
+}
@@ -0,0 +1,319 @@
+package day19
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"stefanvonderkrone/adventOfCode2023/interval"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+type Part struct {
+    A int
+    M int
+    S int
+    X int
+}
+
+// RangedPart holds the ratings of a set of parts, one interval per
+// category in the order of CATEGORIES
+type RangedPart = interval.Box
+
+type Condition struct {
+    Part string
+    Operator string
+    Value int
+    Result string
+    IsResultOnly bool
+}
+
+const CATEGORIES = "xmas"
+
+func isCategory(name string) bool {
+    return len(name) == 1 && strings.Contains(CATEGORIES, name)
+}
+
+func parseConditions(line string) (string, []Condition, error) {
+    conditions := []Condition{}
+    brace := strings.Index(line, "{")
+    if brace <= 0 || !strings.HasSuffix(line, "}") {
+        return "", nil, puzzle.Errorf(1, line, "expected '<name>{<rules>}', found")
+    }
+    key := line[:brace]
+    conditionsField := puzzle.Field{Text: line[brace + 1:len(line) - 1], Column: utf8.RuneCountInString(key) + 2}
+    conditionsFields := conditionsField.Split(",")
+    for _, field := range conditionsFields {
+        parts := field.Split(":")
+        if len(parts) > 2 {
+            return "", nil, field.Errorf("expected '<category><operator><value>:<result>', found")
+        }
+        result := parts[len(parts) - 1]
+        if result.Text == "" {
+            return "", nil, result.Errorf("missing result")
+        }
+        if len(parts) == 1 {
+            conditions = append(conditions, Condition{Result: result.Text, IsResultOnly: true})
+        } else {
+            condition := parts[0]
+            operator := "<"
+            if strings.Contains(condition.Text, ">") {
+                operator = ">"
+            }
+            parts = condition.Split(operator)
+            if len(parts) != 2 || !isCategory(parts[0].Text) {
+                return "", nil, condition.Errorf("expected '<category><operator><value>', found")
+            }
+            part := parts[0].Text
+            value, err := parts[1].Atoi()
+            if err != nil {
+                return "", nil, err
+            }
+            conditions = append(conditions, Condition{part, operator, value, result.Text, false})
+        }
+    }
+    return key, conditions, nil
+}
+
+func parsePart(line string) (Part, error) {
+    if len(line) < 2 || line[0] != '{' || line[len(line) - 1] != '}' {
+        return Part{}, puzzle.Errorf(1, line, "expected '{<ratings>}', found")
+    }
+    components := puzzle.Field{Text: line[1:len(line) - 1], Column: 2}.Split(",")
+    part := Part{}
+    for _, component := range components {
+        rating := component.Split("=")
+        if len(rating) != 2 || !isCategory(rating[0].Text) {
+            return Part{}, component.Errorf("expected '<category>=<value>', found")
+        }
+        name := rating[0].Text
+        value, err := rating[1].Atoi()
+        if err != nil {
+            return Part{}, err
+        }
+        switch name {
+        case "m":
+            part.M = value
+        case "a":
+            part.A = value
+        case "s":
+            part.S = value
+        case "x":
+            part.X = value
+        }
+    }
+    return part, nil
+}
+
+func gt(partValue int,value int) bool {
+    return partValue > value
+}
+
+func lt(partValue int, value int) bool {
+    return partValue < value
+}
+
+var predicates = map[string]func(a int, b int) bool{
+    ">": gt,
+    "<": lt,
+}
+
+func (p *Part) getValue(name string) int {
+    switch name {
+    case "a":
+        return p.A
+    case "m":
+        return p.M
+    case "s":
+        return p.S
+    case "x":
+        return p.X
+    }
+    return 0
+}
+
+func (p *Part) isAccpeted(log *slog.Logger, conditions map[string][]Condition, key string) bool {
+    conditionList, ok := conditions[key]
+    if !ok {
+        return false
+    }
+    for _, condition := range conditionList {
+        log.Debug("condition", "part", *p, "workflow", key, "condition", condition)
+        if condition.IsResultOnly {
+            if condition.Result == "A" {
+                return true
+            }
+            if condition.Result == "R" {
+                return false
+            }
+            return p.isAccpeted(log, conditions, condition.Result)
+        }
+        predicate, ok := predicates[condition.Operator]
+        if !ok {
+            break
+        }
+        if predicate(p.getValue(condition.Part), condition.Value) {
+            if condition.Result == "A" {
+                return true
+            }
+            if condition.Result == "R" {
+                return false
+            }
+            return p.isAccpeted(log, conditions, condition.Result)
+        }
+    }
+    return false
+}
+
+func acceptedRanges(conditions map[string][]Condition, rangedPart RangedPart, key string) []RangedPart {
+    if key == "A" {
+        return []RangedPart{rangedPart}
+    }
+    if key == "R" {
+        return []RangedPart{}
+    }
+    cs, ok := conditions[key]
+    if !ok {
+        return []RangedPart{}
+    }
+    ranges := []RangedPart{}
+    for _, c := range cs {
+        if c.IsResultOnly {
+            return append(ranges, acceptedRanges(conditions, rangedPart, c.Result)...)
+        }
+        // split into the values matching the condition and the ones
+        // falling through to the next condition
+        dim := strings.Index(CATEGORIES, c.Part)
+        var matching, rest RangedPart
+        if c.Operator == "<" {
+            matching, rest = rangedPart.SplitAt(dim, c.Value)
+        } else {
+            rest, matching = rangedPart.SplitAt(dim, c.Value + 1)
+        }
+        if !matching.Empty() {
+            ranges = append(ranges, acceptedRanges(conditions, matching, c.Result)...)
+        }
+        if rest.Empty() {
+            return ranges
+        }
+        rangedPart = rest
+    }
+    return ranges
+}
+
+// checkCycles rejects workflows leading back to themselves, isAccpeted
+// and acceptedRanges would never end on those. It reports the workflow
+// whose rule closes a cycle, searching from in and then the workflows in
+// the order of lines, the line of each.
+func checkCycles(conditions map[string][]Condition, order []string, lines map[string]int) error {
+    const (
+        VISITING = 1
+        VISITED = 2
+    )
+    state := map[string]int{}
+    var visit func(key string) error
+    visit = func(key string) error {
+        state[key] = VISITING
+        for _, condition := range conditions[key] {
+            switch state[condition.Result] {
+            case VISITING:
+                return puzzle.AtLine(puzzle.Errorf(1, key, "cyclic workflow"), lines[key])
+            case VISITED:
+                continue
+            }
+            if err := visit(condition.Result); err != nil {
+                return err
+            }
+        }
+        state[key] = VISITED
+        return nil
+    }
+    for _, key := range append([]string{"in"}, order...) {
+        if state[key] != 0 {
+            continue
+        }
+        if err := visit(key); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func readSystem(r io.Reader) (map[string][]Condition, []Part, error) {
+    scanner := puzzle.NewScanner(r)
+
+    conditions := map[string][]Condition{}
+    order := []string{}
+    lines := map[string]int{}
+
+    for scanner.Scan() {
+        line := scanner.Text()
+
+        if line == "" {
+            break
+        }
+        key, conditionList, err := parseConditions(line)
+        if err != nil {
+            return nil, nil, puzzle.AtLine(err, scanner.Line)
+        }
+
+        conditions[key] = conditionList
+        order = append(order, key)
+        lines[key] = scanner.Line
+    }
+    if err := checkCycles(conditions, order, lines); err != nil {
+        return nil, nil, err
+    }
+    parts := []Part{}
+
+    for scanner.Scan() {
+        line := scanner.Text()
+
+        part, err := parsePart(line)
+        if err != nil {
+            return nil, nil, puzzle.AtLine(err, scanner.Line)
+        }
+        parts = append(parts, part)
+    }
+    return conditions, parts, scanner.Err()
+}
+
+func solve(log *slog.Logger, conditions map[string][]Condition, parts []Part) puzzle.Result {
+    log.Debug("system", "workflows", len(conditions), "parts", len(parts))
+
+    accepted := []Part{}
+
+    for _, part := range parts {
+        if part.isAccpeted(log, conditions, "in") {
+            accepted = append(accepted, part)
+        }
+    }
+    log.Debug("accepted", "parts", len(accepted))
+    sum := 0
+    for _, part := range accepted {
+        sum += part.A + part.M + part.S + part.X
+    }
+
+    rangedPart := RangedPart{
+        interval.Closed(1, 4000),
+        interval.Closed(1, 4000),
+        interval.Closed(1, 4000),
+        interval.Closed(1, 4000),
+    }
+    combinations := 0
+    for _, rp := range acceptedRanges(conditions, rangedPart, "in") {
+        log.Debug("accepted ranges", "x", rp[0], "m", rp[1], "a", rp[2], "s", rp[3])
+        combinations += rp.Volume()
+    }
+    return puzzle.Result{Part1: sum, Part2: combinations}
+}
+
+func Solve(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+    conditions, parts, err := readSystem(r)
+    if err != nil {
+        return puzzle.Result{}, err
+    }
+    return solve(puzzle.Logger(ctx), conditions, parts), nil
+}
@@ -0,0 +1,61 @@
+package day19
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const GEN_LETTERS = "abcdefghijklmnopqrstuvwxyz"
+
+// genNames returns count distinct workflow names, the first one is "in"
+func genNames(rng *rand.Rand, count int) []string {
+    names := []string{"in"}
+    seen := map[string]bool{"in": true}
+    for len(names) < count {
+        name := make([]byte, 2 + rng.Intn(2))
+        for i := range name {
+            name[i] = GEN_LETTERS[rng.Intn(len(GEN_LETTERS))]
+        }
+        if !seen[string(name)] {
+            seen[string(name)] = true
+            names = append(names, string(name))
+        }
+    }
+    return names
+}
+
+// Generate returns size workflows and size parts. A workflow only sends
+// parts to the ones after it, so none is ever visited twice.
+func Generate(rng *rand.Rand, size int) []byte {
+    size = max(size, 1)
+    names := genNames(rng, size)
+    target := func(i int) string {
+        if n := rng.Intn(size - i + 2); n < size - i - 1 {
+            return names[i + 1 + n]
+        }
+        return []string{"A", "R"}[rng.Intn(2)]
+    }
+    workflows := make([]string, size)
+    for i, name := range names {
+        rules := []string{}
+        for r := 1 + rng.Intn(3); r > 0; r-- {
+            rules = append(rules, fmt.Sprintf("%c%c%d:%s", CATEGORIES[rng.Intn(len(CATEGORIES))],
+                "<>"[rng.Intn(2)], 1 + rng.Intn(4000), target(i)))
+        }
+        rules = append(rules, target(i))
+        workflows[i] = fmt.Sprintf("%s{%s}", name, strings.Join(rules, ","))
+    }
+    rng.Shuffle(len(workflows), func(i, j int) {
+        workflows[i], workflows[j] = workflows[j], workflows[i]
+    })
+
+    var b bytes.Buffer
+    b.WriteString(strings.Join(workflows, "\n"))
+    b.WriteString("\n\n")
+    for i := 0; i < size; i++ {
+        fmt.Fprintf(&b, "{x=%d,m=%d,a=%d,s=%d}\n", 1 + rng.Intn(4000), 1 + rng.Intn(4000), 1 + rng.Intn(4000), 1 + rng.Intn(4000))
+    }
+    return b.Bytes()
+}
@@ -0,0 +1,62 @@
+// Package days registers the solvers and input generators of every
+// implemented day.
+package days
+
+import (
+	"sort"
+
+	"stefanvonderkrone/adventOfCode2023/days/day01"
+	"stefanvonderkrone/adventOfCode2023/days/day02"
+	"stefanvonderkrone/adventOfCode2023/days/day03"
+	"stefanvonderkrone/adventOfCode2023/days/day04"
+	"stefanvonderkrone/adventOfCode2023/days/day05"
+	"stefanvonderkrone/adventOfCode2023/days/day07"
+	"stefanvonderkrone/adventOfCode2023/days/day08"
+	"stefanvonderkrone/adventOfCode2023/days/day11"
+	"stefanvonderkrone/adventOfCode2023/days/day12"
+	"stefanvonderkrone/adventOfCode2023/days/day14"
+	"stefanvonderkrone/adventOfCode2023/days/day18"
+	"stefanvonderkrone/adventOfCode2023/days/day19"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+var Solvers = map[int]puzzle.Solver{
+    1: puzzle.SolverFunc(day01.Solve),
+    2: puzzle.SolverFunc(day02.Solve),
+    3: puzzle.SolverFunc(day03.Solve),
+    4: puzzle.SolverFunc(day04.Solve),
+    5: puzzle.SolverFunc(day05.Solve),
+    7: puzzle.SolverFunc(day07.Solve),
+    8: puzzle.SolverFunc(day08.Solve),
+    11: puzzle.SolverFunc(day11.Solve),
+    12: puzzle.SolverFunc(day12.Solve),
+    14: puzzle.SolverFunc(day14.Solve),
+    18: puzzle.SolverFunc(day18.Solve),
+    19: puzzle.SolverFunc(day19.Solve),
+}
+
+// Generators returns random inputs for stress testing the solvers.
+var Generators = map[int]puzzle.Generator{
+    1: day01.Generate,
+    2: day02.Generate,
+    3: day03.Generate,
+    4: day04.Generate,
+    5: day05.Generate,
+    7: day07.Generate,
+    8: day08.Generate,
+    11: day11.Generate,
+    12: day12.Generate,
+    14: day14.Generate,
+    18: day18.Generate,
+    19: day19.Generate,
+}
+
+// Numbers returns the implemented days in ascending order.
+func Numbers() []int {
+    numbers := []int{}
+    for day := range Solvers {
+        numbers = append(numbers, day)
+    }
+    sort.Ints(numbers)
+    return numbers
+}
@@ -0,0 +1,215 @@
+package days
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+// the examples published with each puzzle, some days use a different
+// example for each part
+var examples = []struct {
+    day int
+    input string
+    part int
+    want int
+}{
+    {1, "day01_part1.txt", 1, 142},
+    {1, "day01_part2.txt", 2, 281},
+    {2, "day02.txt", 1, 8},
+    {2, "day02.txt", 2, 2286},
+    {3, "day03.txt", 1, 4361},
+    {3, "day03.txt", 2, 467835},
+    {4, "day04.txt", 1, 13},
+    {4, "day04.txt", 2, 30},
+    {5, "day05.txt", 1, 35},
+    {5, "day05.txt", 2, 46},
+    {7, "day07.txt", 1, 6440},
+    {7, "day07.txt", 2, 5905},
+    {8, "day08_part1.txt", 1, 6},
+    {8, "day08_part2.txt", 2, 6},
+    {11, "day11.txt", 1, 374},
+    {11, "day11.txt", 2, 82000210},
+    {12, "day12.txt", 1, 21},
+    {12, "day12.txt", 2, 525152},
+    {14, "day14.txt", 1, 136},
+    {14, "day14.txt", 2, 64},
+    {18, "day18.txt", 1, 62},
+    {18, "day18.txt", 2, 952408144115},
+    {19, "day19.txt", 1, 19114},
+    {19, "day19.txt", 2, 167409079868000},
+}
+
+func TestExamples(t *testing.T) {
+    for _, example := range examples {
+        example := example
+        t.Run(fmt.Sprintf("day%02d/part%d", example.day, example.part), func(t *testing.T) {
+            solver, ok := Solvers[example.day]
+            if !ok {
+                t.Fatalf("no solver registered for day %d", example.day)
+            }
+            f, err := os.Open(filepath.Join("testdata", example.input))
+            if err != nil {
+                t.Fatal(err)
+            }
+            defer f.Close()
+            result, err := solver.Solve(context.Background(), f)
+            if err != nil {
+                t.Fatalf("Solve(%s): %v", example.input, err)
+            }
+            if got := result.Part(example.part); got != example.want {
+                t.Errorf("Solve(%s) part %d = %d, want %d", example.input, example.part, got, example.want)
+            }
+        })
+    }
+}
+
+// aoc run --all solves days concurrently, run with -race to find
+// solvers sharing state
+func TestConcurrentSolves(t *testing.T) {
+    for _, example := range examples {
+        example := example
+        input, err := os.ReadFile(filepath.Join("testdata", example.input))
+        if err != nil {
+            t.Fatal(err)
+        }
+        for i := 0; i < 2; i++ {
+            t.Run(fmt.Sprintf("day%02d/part%d/%d", example.day, example.part, i), func(t *testing.T) {
+                t.Parallel()
+                result, err := Solvers[example.day].Solve(context.Background(), strings.NewReader(string(input)))
+                if err != nil {
+                    t.Fatal(err)
+                }
+                if got := result.Part(example.part); got != example.want {
+                    t.Errorf("part %d = %d, want %d", example.part, got, example.want)
+                }
+            })
+        }
+    }
+}
+
+func TestInterrupted(t *testing.T) {
+    tests := []struct {
+        day int
+        input string
+        part int
+        part1 int
+    }{
+        // AAA never reaches ZZZ
+        {8, "L\n\nAAA = (BBB, BBB)\nBBB = (AAA, AAA)\n", 1, 0},
+        {12, "???.### 1,1,3\n", 1, 0},
+    }
+    platform, err := os.ReadFile(filepath.Join("testdata", "day14.txt"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    tests = append(tests, struct {
+        day int
+        input string
+        part int
+        part1 int
+    }{14, string(platform), 2, 136})
+    for _, test := range tests {
+        ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+        if test.day != 8 {
+            // these finish too fast for a timeout
+            cancel()
+        }
+        result, err := Solvers[test.day].Solve(ctx, strings.NewReader(test.input))
+        cancel()
+        var interrupted *puzzle.Interrupted
+        if !errors.As(err, &interrupted) || interrupted.Part != test.part || !errors.Is(err, ctx.Err()) {
+            t.Errorf("day %d: err = %v, want part %d interrupted", test.day, err, test.part)
+            continue
+        }
+        if result.Part1 != test.part1 {
+            t.Errorf("day %d: partial part 1 = %d, want %d", test.day, result.Part1, test.part1)
+        }
+    }
+}
+
+func TestEveryDayHasExamples(t *testing.T) {
+    for _, day := range Numbers() {
+        for part := 1; part <= 2; part++ {
+            found := false
+            for _, example := range examples {
+                if example.day == day && example.part == part {
+                    found = true
+                    break
+                }
+            }
+            if !found {
+                t.Errorf("day %d part %d has no example", day, part)
+            }
+        }
+    }
+}
+
+func TestGenerators(t *testing.T) {
+    for _, day := range Numbers() {
+        generate, ok := Generators[day]
+        if !ok {
+            t.Errorf("day %d has no generator", day)
+            continue
+        }
+        for seed := int64(1); seed <= 5; seed++ {
+            input := generate(rand.New(rand.NewSource(seed)), 10 * int(seed))
+            if again := generate(rand.New(rand.NewSource(seed)), 10 * int(seed)); !bytes.Equal(input, again) {
+                t.Errorf("day %d: seed %d generated two inputs", day, seed)
+            }
+            ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Second)
+            _, err := Solvers[day].Solve(ctx, bytes.NewReader(input))
+            cancel()
+            if err != nil {
+                t.Errorf("day %d: seed %d: %v\n%s", day, seed, err, input)
+            }
+        }
+    }
+}
+
+var malformed = []struct {
+    day int
+    input string
+    want string
+}{
+    {2, "Game 1: 3 blue\nGame 2: 3 purple", "line 2, column 11: unknown identifier 'purple'"},
+    {2, "Game 1: 3 blue 4 red", "line 1, column 18: expected token 'INT', found 'red'"},
+    {3, "...\n..", "line 2, column 3: expected 3 columns, found 2"},
+    {4, "Card 1: 41 48 3", "line 1, column 16: expected number or PIPE, found 'EOL'"},
+    {4, "Card 1 41 48", "line 1, column 13: missing ':'"},
+    {5, "seeds: 1 2\n\nseed-to-soil map:\n1 2 x", "line 4, column 5: invalid number 'x': invalid syntax"},
+    {7, "32T3X 4", "line 1, column 5: unknown card 'X'"},
+    {7, "32T3K", "line 1, column 6: missing bid"},
+    {8, "LR\n\nAAA = (BBB, ZZZ)\nBBB", "line 4, column 4: expected a node"},
+    {8, "L\n\n11A = (11B, 11B)\n", "line 3, column 8: unknown node '11B'"},
+    {12, "?x? 1", "line 1, column 2: unknown spring 'x'"},
+    {14, "...\n..", "line 2, column 3: expected 3 columns, found 2"},
+    {18, "R 6 (#70c715)", "line 1, column 12: unknown direction '5'"},
+    {19, "in{q<10:A,R}", "line 1, column 4: expected '<category><operator><value>', found 'q<10'"},
+    {19, "in{R}\n\n{x=1,m=2,a=3,s=y}", "line 3, column 16: invalid number 'y': invalid syntax"},
+    {19, "in{x<5:in,A}\n\n{x=1,m=2,a=3,s=4}", "line 1, column 1: cyclic workflow 'in'"},
+    {19, "px{a<5:A,qs}\nin{x<5:px,A}\nqs{m>2:R,in}\n\n{x=1,m=2,a=3,s=4}", "line 3, column 1: cyclic workflow 'qs'"},
+}
+
+func TestParseErrors(t *testing.T) {
+    for _, example := range malformed {
+        result, err := Solvers[example.day].Solve(context.Background(), strings.NewReader(example.input))
+        var parseErr *puzzle.ParseError
+        if !errors.As(err, &parseErr) {
+            t.Errorf("day %d: Solve(%q) = %+v, %v, want a ParseError", example.day, example.input, result, err)
+            continue
+        }
+        if err.Error() != example.want {
+            t.Errorf("day %d: Solve(%q) error = %q, want %q", example.day, example.input, err, example.want)
+        }
+    }
+}
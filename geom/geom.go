@@ -0,0 +1,85 @@
+// Package geom provides integer points, distances and lattice polygons.
+// Y grows downwards, as in the puzzle inputs.
+package geom
+
+import "stefanvonderkrone/adventOfCode2023/mathx"
+
+type Point struct {
+    X int
+    Y int
+}
+
+var (
+    Up = Point{0, -1}
+    Down = Point{0, 1}
+    Left = Point{-1, 0}
+    Right = Point{1, 0}
+)
+
+// Directions holds the unit vectors of the four orthogonal directions, clockwise starting up.
+var Directions = []Point{Up, Right, Down, Left}
+
+func (p Point) Add(q Point) Point {
+    return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+    return Point{p.X - q.X, p.Y - q.Y}
+}
+
+func (p Point) Scale(factor int) Point {
+    return Point{p.X * factor, p.Y * factor}
+}
+
+func Abs(n int) int {
+    if n < 0 {
+        return -n
+    }
+    return n
+}
+
+func Manhattan(p Point, q Point) int {
+    return Abs(p.X - q.X) + Abs(p.Y - q.Y)
+}
+
+func Chebyshev(p Point, q Point) int {
+    return max(Abs(p.X - q.X), Abs(p.Y - q.Y))
+}
+
+// DoubleArea returns twice the area of the polygon by the shoelace
+// formula, which is always an integer for lattice polygons. The polygon
+// is closed from its last point back to the first.
+func DoubleArea(polygon []Point) int {
+    area := 0
+    for i, p := range polygon {
+        q := polygon[(i + 1) % len(polygon)]
+        area += p.X * q.Y - q.X * p.Y
+    }
+    return Abs(area)
+}
+
+// Area returns the area of the polygon, rounded down.
+func Area(polygon []Point) int {
+    return DoubleArea(polygon) / 2
+}
+
+// Perimeter returns the number of lattice points on the boundary of the
+// polygon, which is its length if all edges are horizontal or vertical.
+func Perimeter(polygon []Point) int {
+    perimeter := 0
+    for i, p := range polygon {
+        d := polygon[(i + 1) % len(polygon)].Sub(p)
+        perimeter += int(mathx.GCD(int64(d.X), int64(d.Y)))
+    }
+    return perimeter
+}
+
+// InteriorPoints returns the number of lattice points strictly inside the
+// polygon by Pick's theorem: A = I + B/2 - 1.
+func InteriorPoints(polygon []Point) int {
+    doubleArea := DoubleArea(polygon)
+    if doubleArea == 0 {
+        return 0
+    }
+    return (doubleArea - Perimeter(polygon)) / 2 + 1
+}
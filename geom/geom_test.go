@@ -0,0 +1,65 @@
+package geom
+
+import "testing"
+
+func TestArithmetic(t *testing.T) {
+    p := Point{3, -2}
+    if got := p.Add(Right.Scale(4)); got != (Point{7, -2}) {
+        t.Errorf("Add = %v", got)
+    }
+    if got := p.Sub(Point{5, 5}); got != (Point{-2, -7}) {
+        t.Errorf("Sub = %v", got)
+    }
+    origin := Point{}
+    for _, d := range Directions {
+        if got := origin.Add(d).Add(d.Scale(-1)); got != origin {
+            t.Errorf("%v and back = %v", d, got)
+        }
+    }
+}
+
+func TestDistances(t *testing.T) {
+    tests := []struct {
+        p, q Point
+        manhattan, chebyshev int
+    }{
+        {Point{0, 0}, Point{0, 0}, 0, 0},
+        {Point{1, 6}, Point{5, 11}, 9, 5},
+        {Point{-3, 4}, Point{2, -1}, 10, 5},
+        {Point{1 << 61, 0}, Point{-(1 << 61), 0}, 1 << 62, 1 << 62},
+    }
+    for _, test := range tests {
+        if got := Manhattan(test.p, test.q); got != test.manhattan {
+            t.Errorf("Manhattan(%v, %v) = %d, want %d", test.p, test.q, got, test.manhattan)
+        }
+        if got := Chebyshev(test.p, test.q); got != test.chebyshev {
+            t.Errorf("Chebyshev(%v, %v) = %d, want %d", test.p, test.q, got, test.chebyshev)
+        }
+    }
+}
+
+func TestPolygons(t *testing.T) {
+    tests := []struct {
+        name string
+        polygon []Point
+        area, perimeter, interior int
+    }{
+        {"square", []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}}, 16, 16, 9},
+        {"square counter clockwise", []Point{{0, 0}, {0, 4}, {4, 4}, {4, 0}}, 16, 16, 9},
+        {"closed explicitly", []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}, 16, 16, 9},
+        {"triangle", []Point{{0, 0}, {4, 0}, {0, 4}}, 8, 12, 3},
+        {"l-shape", []Point{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}, 3, 8, 0},
+        {"line", []Point{{0, 0}, {3, 0}}, 0, 6, 0},
+    }
+    for _, test := range tests {
+        if got := Area(test.polygon); got != test.area {
+            t.Errorf("%s: Area = %d, want %d", test.name, got, test.area)
+        }
+        if got := Perimeter(test.polygon); got != test.perimeter {
+            t.Errorf("%s: Perimeter = %d, want %d", test.name, got, test.perimeter)
+        }
+        if got := InteriorPoints(test.polygon); got != test.interior {
+            t.Errorf("%s: InteriorPoints = %d, want %d", test.name, got, test.interior)
+        }
+    }
+}
@@ -2,4 +2,4 @@ module stefanvonderkrone/adventOfCode2023
 
 go 1.21.3
 
-require golang.org/x/exp v0.0.0-20231127185646-65229373498e // indirect
+require golang.org/x/exp v0.0.0-20231127185646-65229373498e
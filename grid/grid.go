@@ -0,0 +1,207 @@
+// Package grid holds character maps, the most common shape of puzzle input.
+package grid
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+type Pos struct {
+    X int
+    Y int
+}
+
+// Grid is a rectangular map of bytes, (0, 0) is the top left corner.
+type Grid struct {
+    Width int
+    Height int
+    cells []byte
+}
+
+func New(width int, height int, fill byte) *Grid {
+    cells := bytes.Repeat([]byte{fill}, width * height)
+    return &Grid{Width: width, Height: height, cells: cells}
+}
+
+// Read reads one line per row, stopping at the first empty line. All rows
+// must have the same length.
+func Read(r io.Reader) (*Grid, error) {
+    scanner := puzzle.NewScanner(r)
+
+    g := &Grid{}
+    for scanner.Scan() {
+        row := scanner.Bytes()
+        if len(row) == 0 {
+            break
+        }
+        if g.Height == 0 {
+            g.Width = len(row)
+        } else if len(row) != g.Width {
+            return nil, scanner.Errorf(min(len(row), g.Width) + 1, "", "expected %d columns, found %d", g.Width, len(row))
+        }
+        g.cells = append(g.cells, row...)
+        g.Height++
+    }
+    return g, scanner.Err()
+}
+
+func (g *Grid) InBounds(x int, y int) bool {
+    return x >= 0 && x < g.Width && y >= 0 && y < g.Height
+}
+
+// At returns the byte at x, y and false if it lies outside the grid.
+func (g *Grid) At(x int, y int) (byte, bool) {
+    if !g.InBounds(x, y) {
+        return 0, false
+    }
+    return g.cells[y * g.Width + x], true
+}
+
+// Get returns the byte at x, y or 0 if it lies outside the grid.
+func (g *Grid) Get(x int, y int) byte {
+    b, _ := g.At(x, y)
+    return b
+}
+
+// Set changes the byte at x, y, positions outside the grid are ignored.
+func (g *Grid) Set(x int, y int, b byte) {
+    if g.InBounds(x, y) {
+        g.cells[y * g.Width + x] = b
+    }
+}
+
+// Row returns row y, changes to it change the grid.
+func (g *Grid) Row(y int) []byte {
+    return g.cells[y * g.Width:(y + 1) * g.Width]
+}
+
+// Column returns a copy of column x.
+func (g *Grid) Column(x int) []byte {
+    column := make([]byte, g.Height)
+    for y := range column {
+        column[y] = g.cells[y * g.Width + x]
+    }
+    return column
+}
+
+func (g *Grid) SetColumn(x int, column []byte) {
+    for y := 0; y < g.Height && y < len(column); y++ {
+        g.cells[y * g.Width + x] = column[y]
+    }
+}
+
+var (
+    neighbours4 = []Pos{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+    neighbours8 = []Pos{{-1, -1}, {0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}}
+)
+
+func (g *Grid) neighbours(x int, y int, deltas []Pos) []Pos {
+    positions := []Pos{}
+    for _, d := range deltas {
+        if g.InBounds(x + d.X, y + d.Y) {
+            positions = append(positions, Pos{x + d.X, y + d.Y})
+        }
+    }
+    return positions
+}
+
+// Neighbours4 returns the orthogonal neighbours of x, y inside the grid.
+func (g *Grid) Neighbours4(x int, y int) []Pos {
+    return g.neighbours(x, y, neighbours4)
+}
+
+// Neighbours8 returns the orthogonal and diagonal neighbours of x, y inside the grid.
+func (g *Grid) Neighbours8(x int, y int) []Pos {
+    return g.neighbours(x, y, neighbours8)
+}
+
+// FindAll returns the positions of every b, row by row.
+func (g *Grid) FindAll(b byte) []Pos {
+    positions := []Pos{}
+    for i, cell := range g.cells {
+        if cell == b {
+            positions = append(positions, Pos{i % g.Width, i / g.Width})
+        }
+    }
+    return positions
+}
+
+func (g *Grid) Clone() *Grid {
+    return &Grid{Width: g.Width, Height: g.Height, cells: append([]byte{}, g.cells...)}
+}
+
+// transform builds a new grid of the given size where every cell is taken
+// from the position of g that from maps it to.
+func (g *Grid) transform(width int, height int, from func(x int, y int) (int, int)) *Grid {
+    t := New(width, height, 0)
+    for y := 0; y < height; y++ {
+        for x := 0; x < width; x++ {
+            fx, fy := from(x, y)
+            t.cells[y * width + x] = g.cells[fy * g.Width + fx]
+        }
+    }
+    return t
+}
+
+// Transpose mirrors the grid along its main diagonal.
+func (g *Grid) Transpose() *Grid {
+    return g.transform(g.Height, g.Width, func(x int, y int) (int, int) {
+        return y, x
+    })
+}
+
+func (g *Grid) RotateClockwise() *Grid {
+    return g.transform(g.Height, g.Width, func(x int, y int) (int, int) {
+        return y, g.Height - 1 - x
+    })
+}
+
+func (g *Grid) RotateCounterClockwise() *Grid {
+    return g.transform(g.Height, g.Width, func(x int, y int) (int, int) {
+        return g.Width - 1 - y, x
+    })
+}
+
+// FlipHorizontal mirrors the grid left to right.
+func (g *Grid) FlipHorizontal() *Grid {
+    return g.transform(g.Width, g.Height, func(x int, y int) (int, int) {
+        return g.Width - 1 - x, y
+    })
+}
+
+// FlipVertical mirrors the grid top to bottom.
+func (g *Grid) FlipVertical() *Grid {
+    return g.transform(g.Width, g.Height, func(x int, y int) (int, int) {
+        return x, g.Height - 1 - y
+    })
+}
+
+// String returns the rows separated by newlines, it also serves as a key
+// for grids of the same size.
+func (g *Grid) String() string {
+    builder := strings.Builder{}
+    for y := 0; y < g.Height; y++ {
+        if y > 0 {
+            builder.WriteByte('\n')
+        }
+        builder.Write(g.Row(y))
+    }
+    return builder.String()
+}
+
+// Pretty returns the rows inside a border, for debugging.
+func (g *Grid) Pretty() string {
+    border := "+" + strings.Repeat("-", g.Width) + "+\n"
+    builder := strings.Builder{}
+    builder.WriteString(border)
+    for y := 0; y < g.Height; y++ {
+        builder.WriteByte('|')
+        builder.Write(g.Row(y))
+        builder.WriteString("|\n")
+    }
+    builder.WriteString(border)
+    return builder.String()
+}
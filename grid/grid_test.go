@@ -0,0 +1,129 @@
+package grid
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func read(t *testing.T, input string) *Grid {
+    t.Helper()
+    g, err := Read(strings.NewReader(input))
+    if err != nil {
+        t.Fatal(err)
+    }
+    return g
+}
+
+func TestRead(t *testing.T) {
+    g := read(t, "ab.\n#c.\n\nignored")
+    if g.Width != 3 || g.Height != 2 {
+        t.Fatalf("size = %dx%d, want 3x2", g.Width, g.Height)
+    }
+    if got := g.String(); got != "ab.\n#c." {
+        t.Errorf("String() = %q", got)
+    }
+
+    _, err := Read(strings.NewReader("...\n..\n"))
+    if err == nil || err.Error() != "line 2, column 3: expected 3 columns, found 2" {
+        t.Errorf("Read(ragged) error = %v", err)
+    }
+}
+
+func TestAccess(t *testing.T) {
+    g := read(t, "ab\ncd")
+    if b, ok := g.At(1, 1); !ok || b != 'd' {
+        t.Errorf("At(1, 1) = %q, %v", b, ok)
+    }
+    for _, p := range []Pos{{-1, 0}, {2, 0}, {0, -1}, {0, 2}} {
+        if b, ok := g.At(p.X, p.Y); ok || b != 0 {
+            t.Errorf("At(%d, %d) = %q, %v, want out of bounds", p.X, p.Y, b, ok)
+        }
+    }
+    g.Set(0, 1, 'x')
+    g.Set(5, 5, 'y')
+    g.Row(0)[1] = 'z'
+    if got := g.String(); got != "az\nxd" {
+        t.Errorf("after Set String() = %q", got)
+    }
+    if got := string(g.Column(1)); got != "zd" {
+        t.Errorf("Column(1) = %q", got)
+    }
+    g.SetColumn(0, []byte("12"))
+    if got := g.String(); got != "1z\n2d" {
+        t.Errorf("after SetColumn String() = %q", got)
+    }
+}
+
+func TestNeighbours(t *testing.T) {
+    g := New(3, 3, '.')
+    if got := g.Neighbours4(0, 0); !reflect.DeepEqual(got, []Pos{{1, 0}, {0, 1}}) {
+        t.Errorf("Neighbours4(0, 0) = %v", got)
+    }
+    if got := len(g.Neighbours4(1, 1)); got != 4 {
+        t.Errorf("len(Neighbours4(1, 1)) = %d", got)
+    }
+    if got := g.Neighbours8(2, 2); !reflect.DeepEqual(got, []Pos{{1, 1}, {2, 1}, {1, 2}}) {
+        t.Errorf("Neighbours8(2, 2) = %v", got)
+    }
+    if got := len(g.Neighbours8(1, 1)); got != 8 {
+        t.Errorf("len(Neighbours8(1, 1)) = %d", got)
+    }
+}
+
+func TestTransformations(t *testing.T) {
+    g := read(t, "abc\ndef")
+    tests := []struct {
+        name string
+        got *Grid
+        want string
+    }{
+        {"Transpose", g.Transpose(), "ad\nbe\ncf"},
+        {"RotateClockwise", g.RotateClockwise(), "da\neb\nfc"},
+        {"RotateCounterClockwise", g.RotateCounterClockwise(), "cf\nbe\nad"},
+        {"FlipHorizontal", g.FlipHorizontal(), "cba\nfed"},
+        {"FlipVertical", g.FlipVertical(), "def\nabc"},
+        {"four rotations", g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise(), "abc\ndef"},
+    }
+    for _, test := range tests {
+        if got := test.got.String(); got != test.want {
+            t.Errorf("%s = %q, want %q", test.name, got, test.want)
+        }
+    }
+    if g.String() != "abc\ndef" {
+        t.Errorf("transformations changed the original grid")
+    }
+}
+
+func TestFindAll(t *testing.T) {
+    g := read(t, "#.\n.#\n##")
+    want := []Pos{{0, 0}, {1, 1}, {0, 2}, {1, 2}}
+    if got := g.FindAll('#'); !reflect.DeepEqual(got, want) {
+        t.Errorf("FindAll('#') = %v, want %v", got, want)
+    }
+}
+
+func TestPretty(t *testing.T) {
+    g := read(t, "ab\ncd\nef")
+    want := "+--+\n|ab|\n|cd|\n|ef|\n+--+\n"
+    if got := g.Pretty(); got != want {
+        t.Errorf("Pretty() = %q, want %q", got, want)
+    }
+}
+
+func FuzzRead(f *testing.F) {
+    f.Add([]byte("ab.\n#c.\n\nignored"))
+    f.Add([]byte("O....#....\nO.OO#....#\n"))
+    f.Fuzz(func(t *testing.T, input []byte) {
+        g, err := Read(bytes.NewReader(input))
+        if err != nil {
+            return
+        }
+        for y := 0; y < g.Height; y++ {
+            if len(g.Row(y)) != g.Width {
+                t.Errorf("row %d has %d columns, want %d", y, len(g.Row(y)), g.Width)
+            }
+        }
+    })
+}
@@ -0,0 +1,305 @@
+// Package input finds the puzzle input of a day and records which input
+// produced which answers.
+//
+// Inputs are looked up by convention below the store directory:
+//
+//	inputs/2023/day08.txt           the puzzle input of day 8
+//	inputs/2023/day08/example1.txt  its first example
+//	inputs/2023/answers.jsonl       the answers recorded so far
+package input
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+const (
+    DIR = "inputs"
+    YEAR = 2023
+    ANSWERS = "answers.jsonl"
+)
+
+type Store struct {
+    Dir string
+    Year int
+    // Stdin is read if a day has no input file, os.Stdin if nil
+    Stdin io.Reader
+}
+
+func NewStore(dir string) *Store {
+    return &Store{Dir: dir, Year: YEAR}
+}
+
+func (s *Store) yearDir() string {
+    return filepath.Join(s.Dir, fmt.Sprint(s.Year))
+}
+
+// Path returns where the input of day is expected.
+func (s *Store) Path(day int) string {
+    return filepath.Join(s.yearDir(), fmt.Sprintf("day%02d.txt", day))
+}
+
+// ExamplePath returns where the n-th example of day is expected.
+func (s *Store) ExamplePath(day int, n int) string {
+    return filepath.Join(s.yearDir(), fmt.Sprintf("day%02d", day), fmt.Sprintf("example%d.txt", n))
+}
+
+// Save stores data as the puzzle input of day.
+func (s *Store) Save(day int, data []byte) error {
+    path := s.Path(day)
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        return err
+    }
+    return os.WriteFile(path, data, 0o644)
+}
+
+// Source is a loaded input.
+type Source struct {
+    Day int
+    // Name is the path the input was read from or "stdin"
+    Name string
+    Data []byte
+    // SHA256 is the hex encoded hash of Data
+    SHA256 string
+}
+
+func newSource(day int, name string, data []byte) *Source {
+    sum := sha256.Sum256(data)
+    return &Source{day, name, data, hex.EncodeToString(sum[:])}
+}
+
+// Reader returns a new reader over the input.
+func (src *Source) Reader() io.Reader {
+    return bytes.NewReader(src.Data)
+}
+
+func (s *Store) readStdin(day int) (*Source, error) {
+    stdin := s.Stdin
+    if stdin == nil {
+        stdin = os.Stdin
+    }
+    data, err := io.ReadAll(stdin)
+    if err != nil {
+        return nil, err
+    }
+    return newSource(day, "stdin", data), nil
+}
+
+func isTerminal(r io.Reader) bool {
+    f, ok := r.(*os.File)
+    if !ok {
+        return false
+    }
+    info, err := f.Stat()
+    return err == nil && info.Mode() & fs.ModeCharDevice != 0
+}
+
+// Load reads the input of day. An explicit path wins, "-" is stdin.
+// Otherwise the n-th example or, for n == 0, the puzzle input is read
+// from the store. A missing puzzle input falls back to stdin unless
+// that is a terminal.
+func (s *Store) Load(day int, path string, example int) (*Source, error) {
+    if path == "-" {
+        return s.readStdin(day)
+    }
+    if path == "" && example > 0 {
+        path = s.ExamplePath(day, example)
+    }
+    if path != "" {
+        data, err := os.ReadFile(path)
+        if err != nil {
+            return nil, err
+        }
+        return newSource(day, path, data), nil
+    }
+    path = s.Path(day)
+    data, err := os.ReadFile(path)
+    if errors.Is(err, fs.ErrNotExist) {
+        stdin := s.Stdin
+        if stdin == nil {
+            stdin = os.Stdin
+        }
+        if isTerminal(stdin) {
+            return nil, fmt.Errorf("no input for day %d: %s does not exist", day, path)
+        }
+        return s.readStdin(day)
+    }
+    if err != nil {
+        return nil, err
+    }
+    return newSource(day, path, data), nil
+}
+
+// Answer records the result an input produced.
+type Answer struct {
+    Day int `json:"day"`
+    Input string `json:"input"`
+    SHA256 string `json:"sha256"`
+    Part1 int `json:"part1"`
+    Part2 int `json:"part2"`
+    Time time.Time `json:"time"`
+}
+
+func (a Answer) Result() puzzle.Result {
+    return puzzle.Result{Part1: a.Part1, Part2: a.Part2}
+}
+
+// Check returns an error if result differs from the recorded answer.
+func (a Answer) Check(result puzzle.Result) error {
+    if a.Result() == result {
+        return nil
+    }
+    return fmt.Errorf("expected %d and %d as recorded at %s, got %d and %d",
+        a.Part1, a.Part2, a.Time.Format("2006-01-02 15:04"), result.Part1, result.Part2)
+}
+
+// Record appends the result src produced to the answers of the store.
+func (s *Store) Record(src *Source, result puzzle.Result) error {
+    if err := os.MkdirAll(s.yearDir(), 0o755); err != nil {
+        return err
+    }
+    f, err := os.OpenFile(filepath.Join(s.yearDir(), ANSWERS), os.O_APPEND | os.O_CREATE | os.O_WRONLY, 0o644)
+    if err != nil {
+        return err
+    }
+    line, err := json.Marshal(Answer{src.Day, src.Name, src.SHA256, result.Part1, result.Part2, time.Now().UTC()})
+    if err != nil {
+        f.Close()
+        return err
+    }
+    if _, err := f.Write(append(line, '\n')); err != nil {
+        f.Close()
+        return err
+    }
+    return f.Close()
+}
+
+// Answers returns every recorded answer, oldest first.
+func (s *Store) Answers() ([]Answer, error) {
+    path := filepath.Join(s.yearDir(), ANSWERS)
+    data, err := os.ReadFile(path)
+    if errors.Is(err, fs.ErrNotExist) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    answers := []Answer{}
+    for i, line := range bytes.Split(data, []byte("\n")) {
+        if len(bytes.TrimSpace(line)) == 0 {
+            continue
+        }
+        answer := Answer{}
+        if err := json.Unmarshal(line, &answer); err != nil {
+            return nil, fmt.Errorf("%s, line %d: %w", path, i + 1, err)
+        }
+        answers = append(answers, answer)
+    }
+    return answers, nil
+}
+
+// Recorded returns the last answer recorded for the input of day with the
+// given hash.
+func (s *Store) Recorded(day int, sha string) (Answer, bool, error) {
+    answers, err := s.Answers()
+    if err != nil {
+        return Answer{}, false, err
+    }
+    for i := len(answers) - 1; i >= 0; i-- {
+        if answers[i].Day == day && answers[i].SHA256 == sha {
+            return answers[i], true, nil
+        }
+    }
+    return Answer{}, false, nil
+}
+
+// Flags are the command line flags selecting an input.
+type Flags struct {
+    Dir string
+    Path string
+    Example int
+    NoRecord bool
+}
+
+// RegisterFlags adds -inputs, -input, -example and -no-record to flags.
+func RegisterFlags(flags *flag.FlagSet) *Flags {
+    f := &Flags{}
+    flags.StringVar(&f.Dir, "inputs", DIR, "directory of the input store")
+    flags.StringVar(&f.Path, "input", "", "input file, - for stdin, the store if omitted")
+    flags.IntVar(&f.Example, "example", 0, "read the n-th example of the day from the store")
+    flags.BoolVar(&f.NoRecord, "no-record", false, "do not record the answers in the store")
+    return f
+}
+
+func (f *Flags) Store() *Store {
+    return NewStore(f.Dir)
+}
+
+// Solution is what solving an input produced.
+type Solution struct {
+    Source *Source
+    Result puzzle.Result
+    // Duration is the time taken to solve both parts, without loading
+    Duration time.Duration
+}
+
+// Run loads the input of day selected by f, solves it and records the
+// answers unless disabled. It is meant for the main of a single day.
+// Answers already recorded for the input are checked rather than
+// recorded again, a mismatch is returned as an error with the solution.
+// If solving was interrupted the partial solution is returned with the
+// error.
+func (f *Flags) Run(ctx context.Context, day int, solve puzzle.SolverFunc) (*Solution, error) {
+    store := f.Store()
+    src, err := store.Load(day, f.Path, f.Example)
+    if err != nil {
+        return nil, err
+    }
+    solution, err := Solve(ctx, src, solve)
+    if err != nil || f.NoRecord {
+        return solution, err
+    }
+    answer, ok, err := store.Recorded(day, src.SHA256)
+    if err != nil {
+        return nil, err
+    }
+    if ok {
+        if err := answer.Check(solution.Result); err != nil {
+            return solution, fmt.Errorf("%s: %w", src.Name, err)
+        }
+        return solution, nil
+    }
+    if err := store.Record(src, solution.Result); err != nil {
+        return nil, err
+    }
+    return solution, nil
+}
+
+// Solve solves src and times it. If solving was interrupted the partial
+// solution is returned with the error.
+func Solve(ctx context.Context, src *Source, solve puzzle.SolverFunc) (*Solution, error) {
+    start := time.Now()
+    result, err := solve(ctx, src.Reader())
+    duration := time.Since(start)
+    var interrupted *puzzle.Interrupted
+    if errors.As(err, &interrupted) {
+        return &Solution{src, result, duration}, err
+    }
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", src.Name, err)
+    }
+    return &Solution{src, result, duration}, nil
+}
@@ -0,0 +1,105 @@
+package input
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func TestLoad(t *testing.T) {
+    store := NewStore(t.TempDir())
+    store.Stdin = strings.NewReader("from stdin\n")
+    if got := store.Path(8); got != filepath.Join(store.Dir, "2023", "day08.txt") {
+        t.Errorf("Path(8) = %s", got)
+    }
+
+    src, err := store.Load(8, "", 0)
+    if err != nil || src.Name != "stdin" || string(src.Data) != "from stdin\n" {
+        t.Fatalf("missing input did not fall back to stdin: %+v, %v", src, err)
+    }
+
+    for _, path := range []string{store.Path(8), store.ExamplePath(8, 2)} {
+        if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+            t.Fatal(err)
+        }
+        if err := os.WriteFile(path, []byte(path), 0o644); err != nil {
+            t.Fatal(err)
+        }
+    }
+    src, err = store.Load(8, "", 0)
+    if err != nil || src.Name != store.Path(8) {
+        t.Errorf("Load(8) = %+v, %v", src, err)
+    }
+    src, err = store.Load(8, "", 2)
+    if err != nil || src.Name != store.ExamplePath(8, 2) {
+        t.Errorf("Load(8, example 2) = %+v, %v", src, err)
+    }
+    if _, err := store.Load(8, "", 3); err == nil {
+        t.Errorf("missing example did not fail")
+    }
+    // sha256 of "abc"
+    if src := newSource(1, "", []byte("abc")); src.SHA256 != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+        t.Errorf("SHA256 = %s", src.SHA256)
+    }
+}
+
+func TestRecord(t *testing.T) {
+    store := NewStore(t.TempDir())
+    if _, ok, err := store.Recorded(1, "x"); ok || err != nil {
+        t.Fatalf("empty store has answers: %v, %v", ok, err)
+    }
+    a := newSource(1, "a", []byte("a"))
+    b := newSource(1, "b", []byte("b"))
+    for _, record := range []struct {
+        src *Source
+        result puzzle.Result
+    }{
+        {a, puzzle.Result{Part1: 1, Part2: 2}},
+        {b, puzzle.Result{Part1: 3, Part2: 4}},
+        {a, puzzle.Result{Part1: 5, Part2: 6}},
+    } {
+        if err := store.Record(record.src, record.result); err != nil {
+            t.Fatal(err)
+        }
+    }
+    answer, ok, err := store.Recorded(1, a.SHA256)
+    if err != nil || !ok || answer.Result() != (puzzle.Result{Part1: 5, Part2: 6}) || answer.Input != "a" {
+        t.Errorf("Recorded(a) = %+v, %v, %v", answer, ok, err)
+    }
+    if _, ok, _ := store.Recorded(2, a.SHA256); ok {
+        t.Errorf("answer of day 1 recorded for day 2")
+    }
+}
+
+func TestRunChecksRecorded(t *testing.T) {
+    f := &Flags{Dir: t.TempDir()}
+    path := NewStore(f.Dir).Path(3)
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        t.Fatal(err)
+    }
+    if err := os.WriteFile(path, []byte("input"), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    result := puzzle.Result{Part1: 1, Part2: 2}
+    solve := func(ctx context.Context, r io.Reader) (puzzle.Result, error) {
+        return result, nil
+    }
+    for i := 0; i < 2; i++ {
+        if _, err := f.Run(context.Background(), 3, solve); err != nil {
+            t.Fatalf("run %d: %v", i + 1, err)
+        }
+    }
+    result.Part2 = 3
+    if _, err := f.Run(context.Background(), 3, solve); err == nil {
+        t.Errorf("changed answer was not reported")
+    }
+    answers, err := f.Store().Answers()
+    if err != nil || len(answers) != 1 || answers[0].Result() != (puzzle.Result{Part1: 1, Part2: 2}) {
+        t.Errorf("Answers() = %+v, %v, want the first answer once", answers, err)
+    }
+}
@@ -0,0 +1,229 @@
+// Package interval provides integer intervals, unions of them and
+// multi-dimensional boxes built from them.
+package interval
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Interval is the half-open interval [Start, End). It is empty if End is
+// not greater than Start.
+type Interval struct {
+    Start int
+    End int
+}
+
+// Closed returns the interval [first, last].
+func Closed(first int, last int) Interval {
+    return Interval{first, last + 1}
+}
+
+// OfLength returns the interval of length values starting at start.
+func OfLength(start int, length int) Interval {
+    return Interval{start, start + length}
+}
+
+func (i Interval) Empty() bool {
+    return i.End <= i.Start
+}
+
+// Len returns the number of values in the interval.
+func (i Interval) Len() int {
+    if i.Empty() {
+        return 0
+    }
+    return i.End - i.Start
+}
+
+// Last returns the greatest value in the interval, the closed end.
+func (i Interval) Last() int {
+    return i.End - 1
+}
+
+func (i Interval) Contains(x int) bool {
+    return x >= i.Start && x < i.End
+}
+
+// Shift moves the interval by delta.
+func (i Interval) Shift(delta int) Interval {
+    return Interval{i.Start + delta, i.End + delta}
+}
+
+// Intersect returns the values in both intervals, the zero Interval if
+// there are none.
+func (i Interval) Intersect(o Interval) Interval {
+    r := Interval{max(i.Start, o.Start), min(i.End, o.End)}
+    if r.Empty() {
+        return Interval{}
+    }
+    return r
+}
+
+// Difference returns the values of i not in o as up to two intervals,
+// in ascending order.
+func (i Interval) Difference(o Interval) []Interval {
+    if i.Empty() {
+        return nil
+    }
+    if i.Intersect(o).Empty() {
+        return []Interval{i}
+    }
+    pieces := []Interval{}
+    if i.Start < o.Start {
+        pieces = append(pieces, Interval{i.Start, o.Start})
+    }
+    if o.End < i.End {
+        pieces = append(pieces, Interval{o.End, i.End})
+    }
+    return pieces
+}
+
+// SplitAt returns the values of i less than pivot and the rest. Either
+// may be empty.
+func (i Interval) SplitAt(pivot int) (Interval, Interval) {
+    pivot = min(max(pivot, i.Start), max(i.End, i.Start))
+    return Interval{i.Start, pivot}, Interval{pivot, i.End}
+}
+
+func (i Interval) String() string {
+    return fmt.Sprintf("[%d, %d)", i.Start, i.End)
+}
+
+// Set is a union of intervals, kept as disjoint, non-adjacent and
+// non-empty intervals in ascending order.
+type Set []Interval
+
+// Union returns the set of all values in any of the intervals.
+func Union(intervals ...Interval) Set {
+    sorted := make([]Interval, 0, len(intervals))
+    for _, i := range intervals {
+        if !i.Empty() {
+            sorted = append(sorted, i)
+        }
+    }
+    sort.Slice(sorted, func(a int, b int) bool {
+        return sorted[a].Start < sorted[b].Start
+    })
+    s := Set{}
+    for _, i := range sorted {
+        if last := len(s) - 1; last >= 0 && i.Start <= s[last].End {
+            s[last].End = max(s[last].End, i.End)
+            continue
+        }
+        s = append(s, i)
+    }
+    return s
+}
+
+// Len returns the number of values in the set.
+func (s Set) Len() int {
+    n := 0
+    for _, i := range s {
+        n += i.Len()
+    }
+    return n
+}
+
+func (s Set) Contains(x int) bool {
+    k := sort.Search(len(s), func(k int) bool {
+        return s[k].End > x
+    })
+    return k < len(s) && s[k].Contains(x)
+}
+
+func (s Set) Union(o Set) Set {
+    return Union(append(append([]Interval{}, s...), o...)...)
+}
+
+func (s Set) Intersect(o Set) Set {
+    r := Set{}
+    a, b := 0, 0
+    for a < len(s) && b < len(o) {
+        if i := s[a].Intersect(o[b]); !i.Empty() {
+            r = append(r, i)
+        }
+        if s[a].End < o[b].End {
+            a++
+        } else {
+            b++
+        }
+    }
+    return r
+}
+
+func (s Set) Difference(o Set) Set {
+    r := Set{}
+    for _, i := range s {
+        pieces := []Interval{i}
+        for _, j := range o {
+            next := []Interval{}
+            for _, piece := range pieces {
+                next = append(next, piece.Difference(j)...)
+            }
+            pieces = next
+        }
+        r = append(r, pieces...)
+    }
+    return r
+}
+
+// Box is the product of one interval per dimension.
+type Box []Interval
+
+func (b Box) Empty() bool {
+    for _, i := range b {
+        if i.Empty() {
+            return true
+        }
+    }
+    return false
+}
+
+// Volume returns the number of points in the box.
+func (b Box) Volume() int {
+    if len(b) == 0 {
+        return 0
+    }
+    volume := 1
+    for _, i := range b {
+        volume *= i.Len()
+    }
+    return volume
+}
+
+func (b Box) Contains(point ...int) bool {
+    if len(point) != len(b) {
+        return false
+    }
+    for dim, i := range b {
+        if !i.Contains(point[dim]) {
+            return false
+        }
+    }
+    return true
+}
+
+// With returns a copy of the box with dimension dim replaced by i.
+func (b Box) With(dim int, i Interval) Box {
+    c := append(Box{}, b...)
+    c[dim] = i
+    return c
+}
+
+// Intersect returns the box of points in both boxes, which must have the
+// same number of dimensions.
+func (b Box) Intersect(o Box) Box {
+    r := make(Box, len(b))
+    for dim := range b {
+        r[dim] = b[dim].Intersect(o[dim])
+    }
+    return r
+}
+
+// SplitAt splits the box in dimension dim into the points less than pivot
+// and the rest.
+func (b Box) SplitAt(dim int, pivot int) (Box, Box) {
+    lower, upper := b[dim].SplitAt(pivot)
+    return b.With(dim, lower), b.With(dim, upper)
+}
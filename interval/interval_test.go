@@ -0,0 +1,93 @@
+package interval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+    i := Closed(10, 19)
+    if i != (Interval{10, 20}) || i.Len() != 10 || i.Last() != 19 {
+        t.Errorf("Closed(10, 19) = %v, Len %d, Last %d", i, i.Len(), i.Last())
+    }
+    if !i.Contains(10) || !i.Contains(19) || i.Contains(20) || i.Contains(9) {
+        t.Errorf("%v contains the wrong values", i)
+    }
+    if e := (Interval{5, 3}); !e.Empty() || e.Len() != 0 {
+        t.Errorf("%v should be empty", e)
+    }
+    if got := i.Shift(-10); got != OfLength(0, 10) {
+        t.Errorf("Shift = %v", got)
+    }
+}
+
+func TestIntersectAndDifference(t *testing.T) {
+    tests := []struct {
+        a, b Interval
+        intersection Interval
+        difference []Interval
+    }{
+        {Interval{0, 10}, Interval{3, 5}, Interval{3, 5}, []Interval{{0, 3}, {5, 10}}},
+        {Interval{0, 10}, Interval{5, 15}, Interval{5, 10}, []Interval{{0, 5}}},
+        {Interval{0, 10}, Interval{-5, 5}, Interval{0, 5}, []Interval{{5, 10}}},
+        {Interval{0, 10}, Interval{10, 15}, Interval{}, []Interval{{0, 10}}},
+        {Interval{3, 5}, Interval{0, 10}, Interval{3, 5}, []Interval{}},
+        {Interval{}, Interval{0, 10}, Interval{}, nil},
+    }
+    for _, test := range tests {
+        if got := test.a.Intersect(test.b); got != test.intersection {
+            t.Errorf("%v.Intersect(%v) = %v, want %v", test.a, test.b, got, test.intersection)
+        }
+        if got := test.a.Difference(test.b); !reflect.DeepEqual(got, test.difference) {
+            t.Errorf("%v.Difference(%v) = %v, want %v", test.a, test.b, got, test.difference)
+        }
+    }
+}
+
+func TestSplitAt(t *testing.T) {
+    i := Interval{10, 20}
+    for pivot, want := range map[int][2]Interval{
+        15: {{10, 15}, {15, 20}},
+        5: {{10, 10}, {10, 20}},
+        25: {{10, 20}, {20, 20}},
+    } {
+        lower, upper := i.SplitAt(pivot)
+        if lower != want[0] || upper != want[1] {
+            t.Errorf("SplitAt(%d) = %v, %v, want %v", pivot, lower, upper, want)
+        }
+    }
+}
+
+func TestSet(t *testing.T) {
+    s := Union(Interval{10, 20}, Interval{0, 5}, Interval{5, 7}, Interval{15, 25}, Interval{30, 30})
+    if want := (Set{{0, 7}, {10, 25}}); !reflect.DeepEqual(s, want) {
+        t.Fatalf("Union = %v, want %v", s, want)
+    }
+    if s.Len() != 22 || !s.Contains(6) || s.Contains(7) || !s.Contains(24) {
+        t.Errorf("%v: wrong Len %d or membership", s, s.Len())
+    }
+    o := Union(Interval{3, 12}, Interval{20, 40})
+    if got, want := s.Intersect(o), (Set{{3, 7}, {10, 12}, {20, 25}}); !reflect.DeepEqual(got, want) {
+        t.Errorf("Intersect = %v, want %v", got, want)
+    }
+    if got, want := s.Difference(o), (Set{{0, 3}, {12, 20}}); !reflect.DeepEqual(got, want) {
+        t.Errorf("Difference = %v, want %v", got, want)
+    }
+    if got, want := s.Union(o), (Set{{0, 40}}); !reflect.DeepEqual(got, want) {
+        t.Errorf("Union = %v, want %v", got, want)
+    }
+}
+
+func TestBox(t *testing.T) {
+    b := Box{Closed(1, 4000), Closed(1, 4000)}
+    if b.Volume() != 16000000 || !b.Contains(1, 4000) || b.Contains(0, 1) {
+        t.Errorf("%v: wrong Volume %d or membership", b, b.Volume())
+    }
+    lower, upper := b.SplitAt(1, 1001)
+    if lower.Volume() != 4000000 || upper.Volume() != 12000000 || b[1] != Closed(1, 4000) {
+        t.Errorf("SplitAt = %v, %v and changed %v", lower, upper, b)
+    }
+    if got := lower.Intersect(upper); !got.Empty() || got.Volume() != 0 {
+        t.Errorf("halves intersect in %v", got)
+    }
+}
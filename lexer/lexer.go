@@ -0,0 +1,148 @@
+// Package lexer splits a line of puzzle input into tokens. What counts as
+// a keyword or as punctuation is configured per puzzle format.
+package lexer
+
+import (
+	"unicode"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+type TokenType string
+
+const (
+    INT = "INT"
+    IDENT = "IDENT"
+    EOL = "EOL"
+)
+
+type Token struct {
+    Type TokenType
+    Literal string
+    // 1-based column of the first rune of the token
+    Column int
+}
+
+// Config describes a puzzle format. Keywords maps identifiers to their
+// token type, identifiers not listed are IDENT tokens when AllowIdentifiers
+// is set and errors otherwise. Punctuation maps single runes to their
+// token type.
+type Config struct {
+    Keywords map[string]TokenType
+    Punctuation map[rune]TokenType
+    AllowIdentifiers bool
+}
+
+type Lexer struct {
+    config Config
+    input []rune
+    position int
+    readPosition int
+    char rune
+}
+
+func New(input string, config Config) *Lexer {
+    lexer := Lexer{config: config, input: []rune(input)}
+    lexer.readChar()
+    return &lexer
+}
+
+func isDigit(char rune) bool {
+    return char >= '0' && char <= '9'
+}
+
+func (l *Lexer) readChar() {
+    if l.readPosition >= len(l.input) {
+        l.char = 0
+    } else {
+        l.char = l.input[l.readPosition]
+    }
+    l.position = l.readPosition
+    l.readPosition += 1
+}
+
+func (l *Lexer) read(predicate func(rune) bool) string {
+    position := l.position
+    for predicate(l.char) {
+        l.readChar()
+    }
+    return string(l.input[position:l.position])
+}
+
+func (l *Lexer) skipWhitespace() {
+    for l.char == ' ' || l.char == '\t' {
+        l.readChar()
+    }
+}
+
+// SkipPast discards everything up to and including the next occurrence of char.
+func (l *Lexer) SkipPast(char rune) error {
+    for l.char != char {
+        if l.position >= len(l.input) {
+            return puzzle.Errorf(l.position + 1, "", "missing '%c'", char)
+        }
+        l.readChar()
+    }
+    l.readChar()
+    return nil
+}
+
+// NextToken returns the next token of the line, EOL once the line is
+// exhausted.
+func (l *Lexer) NextToken() (Token, error) {
+    l.skipWhitespace()
+
+    char := l.char
+    column := l.position + 1
+
+    if l.position >= len(l.input) {
+        return Token{Type: EOL, Column: column}, nil
+    }
+    if tokenType, ok := l.config.Punctuation[char]; ok {
+        l.readChar()
+        return Token{Type: tokenType, Literal: string(char), Column: column}, nil
+    }
+    if isDigit(char) {
+        return Token{Type: INT, Literal: l.read(isDigit), Column: column}, nil
+    }
+    if unicode.IsLetter(char) {
+        identifier := l.read(unicode.IsLetter)
+        if tokenType, ok := l.config.Keywords[identifier]; ok {
+            return Token{Type: tokenType, Literal: identifier, Column: column}, nil
+        }
+        if l.config.AllowIdentifiers {
+            return Token{Type: IDENT, Literal: identifier, Column: column}, nil
+        }
+        return Token{}, puzzle.Errorf(column, identifier, "unknown identifier")
+    }
+    return Token{}, puzzle.Errorf(column, string(char), "unknown char")
+}
+
+// Tokens returns all remaining tokens of the line, including the final EOL.
+func (l *Lexer) Tokens() ([]Token, error) {
+    tokens := []Token{}
+    for {
+        token, err := l.NextToken()
+        if err != nil {
+            return nil, err
+        }
+        tokens = append(tokens, token)
+        if token.Type == EOL {
+            return tokens, nil
+        }
+    }
+}
+
+// Errorf returns a ParseError pointing at the token.
+func (t Token) Errorf(format string, args ...any) *puzzle.ParseError {
+    text := t.Literal
+    if text == "" {
+        text = string(t.Type)
+    }
+    return puzzle.Errorf(t.Column, text, format, args...)
+}
+
+// Atoi converts the literal of an INT token.
+func (t Token) Atoi() (int, error) {
+    return puzzle.Field{Text: t.Literal, Column: t.Column}.Atoi()
+}
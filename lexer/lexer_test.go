@@ -0,0 +1,128 @@
+package lexer
+
+import (
+	"reflect"
+	"testing"
+)
+
+var workflows = Config{
+    Punctuation: map[rune]TokenType{
+        '{': "{",
+        '}': "}",
+        '<': "<",
+        '>': ">",
+        ':': ":",
+        ',': ",",
+    },
+    AllowIdentifiers: true,
+}
+
+func TestTokens(t *testing.T) {
+    tests := []struct {
+        input string
+        config Config
+        want []Token
+    }{
+        {
+            "Game 12: 3 blue",
+            Config{
+                Keywords: map[string]TokenType{"Game": "GAME", "blue": "BLUE"},
+                Punctuation: map[rune]TokenType{':': "COLON"},
+            },
+            []Token{
+                {"GAME", "Game", 1},
+                {INT, "12", 6},
+                {"COLON", ":", 8},
+                {INT, "3", 10},
+                {"BLUE", "blue", 12},
+                {EOL, "", 16},
+            },
+        },
+        {
+            "px{a<2006:qkq,A}",
+            workflows,
+            []Token{
+                {IDENT, "px", 1},
+                {"{", "{", 3},
+                {IDENT, "a", 4},
+                {"<", "<", 5},
+                {INT, "2006", 6},
+                {":", ":", 10},
+                {IDENT, "qkq", 11},
+                {",", ",", 14},
+                {IDENT, "A", 15},
+                {"}", "}", 16},
+                {EOL, "", 17},
+            },
+        },
+        {
+            "seed-to-soil map:",
+            Config{
+                Punctuation: map[rune]TokenType{'-': "-", ':': ":"},
+                AllowIdentifiers: true,
+            },
+            []Token{
+                {IDENT, "seed", 1},
+                {"-", "-", 5},
+                {IDENT, "to", 6},
+                {"-", "-", 8},
+                {IDENT, "soil", 9},
+                {IDENT, "map", 14},
+                {":", ":", 17},
+                {EOL, "", 18},
+            },
+        },
+        {
+            "\t ",
+            Config{},
+            []Token{{EOL, "", 3}},
+        },
+    }
+    for _, test := range tests {
+        got, err := New(test.input, test.config).Tokens()
+        if err != nil {
+            t.Errorf("Tokens(%q): %v", test.input, err)
+            continue
+        }
+        if !reflect.DeepEqual(got, test.want) {
+            t.Errorf("Tokens(%q) = %v, want %v", test.input, got, test.want)
+        }
+    }
+}
+
+func TestErrors(t *testing.T) {
+    tests := []struct {
+        input string
+        config Config
+        want string
+    }{
+        {"3 purple", Config{Keywords: map[string]TokenType{"red": "RED"}}, "column 3: unknown identifier 'purple'"},
+        {"3 $", Config{}, "column 3: unknown char '$'"},
+        {"äb 3 #", Config{AllowIdentifiers: true}, "column 6: unknown char '#'"},
+    }
+    for _, test := range tests {
+        _, err := New(test.input, test.config).Tokens()
+        if err == nil || err.Error() != test.want {
+            t.Errorf("Tokens(%q) error = %v, want %q", test.input, err, test.want)
+        }
+    }
+}
+
+func TestSkipPast(t *testing.T) {
+    l := New("Card 1: 41 | 83", Config{Punctuation: map[rune]TokenType{'|': "PIPE"}})
+    if err := l.SkipPast(':'); err != nil {
+        t.Fatal(err)
+    }
+    token, err := l.NextToken()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if want := (Token{INT, "41", 9}); token != want {
+        t.Errorf("NextToken() = %v, want %v", token, want)
+    }
+
+    err = New("Card 1", Config{}).SkipPast(':')
+    if err == nil || err.Error() != "column 7: missing ':'" {
+        t.Errorf("SkipPast(':') error = %v, want missing ':'", err)
+    }
+}
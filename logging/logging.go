@@ -0,0 +1,94 @@
+// Package logging configures the loggers handed to the solvers by the
+// runners, with a level per day.
+//
+// The levels are given as a comma separated list of a default level and
+// day=level pairs, e.g. "info,8=debug" or "14=debug".
+package logging
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+// Levels holds the log level of every day, usable as a flag.
+type Levels struct {
+    Default slog.Level
+    Days map[int]slog.Level
+}
+
+func NewLevels() *Levels {
+    return &Levels{Default: slog.LevelWarn, Days: map[int]slog.Level{}}
+}
+
+// RegisterFlag adds -log to flags.
+func RegisterFlag(flags *flag.FlagSet) *Levels {
+    l := NewLevels()
+    flags.Var(l, "log", "log levels to stderr, e.g. debug or info,8=debug")
+    return l
+}
+
+func (l *Levels) String() string {
+    if l == nil {
+        return ""
+    }
+    items := []string{strings.ToLower(l.Default.String())}
+    days := []int{}
+    for day := range l.Days {
+        days = append(days, day)
+    }
+    sort.Ints(days)
+    for _, day := range days {
+        items = append(items, fmt.Sprintf("%d=%s", day, strings.ToLower(l.Days[day].String())))
+    }
+    return strings.Join(items, ",")
+}
+
+func (l *Levels) Set(value string) error {
+    for _, item := range strings.Split(value, ",") {
+        dayText, levelText, isDay := strings.Cut(item, "=")
+        if !isDay {
+            levelText = item
+        }
+        var level slog.Level
+        if err := level.UnmarshalText([]byte(strings.TrimSpace(levelText))); err != nil {
+            return fmt.Errorf("invalid level '%s'", levelText)
+        }
+        if !isDay {
+            l.Default = level
+            continue
+        }
+        day, err := strconv.Atoi(strings.TrimSpace(dayText))
+        if err != nil {
+            return fmt.Errorf("invalid day '%s'", dayText)
+        }
+        l.Days[day] = level
+    }
+    return nil
+}
+
+// Level returns the level of day.
+func (l *Levels) Level(day int) slog.Level {
+    if level, ok := l.Days[day]; ok {
+        return level
+    }
+    return l.Default
+}
+
+// Logger returns a logger writing the events of day at its level to w.
+func (l *Levels) Logger(w io.Writer, day int) *slog.Logger {
+    handler := slog.NewTextHandler(w, &slog.HandlerOptions{Level: l.Level(day)})
+    return slog.New(handler).With("day", day)
+}
+
+// Context returns ctx handing the logger of day to its solver.
+func (l *Levels) Context(ctx context.Context, w io.Writer, day int) context.Context {
+    return puzzle.WithLogger(ctx, l.Logger(w, day))
+}
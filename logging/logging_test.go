@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func TestSet(t *testing.T) {
+    l := NewLevels()
+    if err := l.Set("info,8=debug, 14 = error"); err != nil {
+        t.Fatal(err)
+    }
+    if l.Level(1) != slog.LevelInfo || l.Level(8) != slog.LevelDebug || l.Level(14) != slog.LevelError {
+        t.Errorf("levels = %s", l)
+    }
+    if got, want := l.String(), "info,8=debug,14=error"; got != want {
+        t.Errorf("String() = %s, want %s", got, want)
+    }
+    for _, value := range []string{"loud", "x=debug", "8=loud"} {
+        if err := NewLevels().Set(value); err == nil {
+            t.Errorf("Set(%s) succeeded", value)
+        }
+    }
+}
+
+func TestContext(t *testing.T) {
+    l := NewLevels()
+    l.Set("8=debug")
+    var b bytes.Buffer
+    puzzle.Logger(l.Context(context.Background(), &b, 8)).Debug("step", "node", "AAA")
+    puzzle.Logger(l.Context(context.Background(), &b, 4)).Debug("score")
+    if got := b.String(); !strings.Contains(got, "msg=step day=8 node=AAA") || strings.Contains(got, "score") {
+        t.Errorf("logged %q", got)
+    }
+    // without a logger the events are dropped
+    puzzle.Logger(context.Background()).Error("dropped")
+}
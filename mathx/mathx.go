@@ -0,0 +1,153 @@
+// Package mathx provides exact number theory helpers for int64 and
+// *big.Int.
+package mathx
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+var (
+    ErrOverflow = errors.New("result overflows int64")
+    ErrNoSolution = errors.New("congruences have no common solution")
+)
+
+func abs(n int64) uint64 {
+    if n < 0 {
+        return uint64(-(n + 1)) + 1
+    }
+    return uint64(n)
+}
+
+func gcd(a uint64, b uint64) uint64 {
+    for b != 0 {
+        a, b = b, a % b
+    }
+    return a
+}
+
+// GCD returns the non-negative greatest common divisor of a and b,
+// GCD(0, 0) is 0. It only overflows for GCD(MinInt64, 0) and
+// GCD(MinInt64, MinInt64), which return MinInt64.
+func GCD(a int64, b int64) int64 {
+    return int64(gcd(abs(a), abs(b)))
+}
+
+// LCM returns the non-negative least common multiple of a and b or
+// ErrOverflow if it does not fit into an int64. LCM(a, 0) is 0.
+func LCM(a int64, b int64) (int64, error) {
+    ua, ub := abs(a), abs(b)
+    if ua == 0 || ub == 0 {
+        return 0, nil
+    }
+    hi, lo := bits.Mul64(ua / gcd(ua, ub), ub)
+    if hi != 0 || lo > math.MaxInt64 {
+        return 0, ErrOverflow
+    }
+    return int64(lo), nil
+}
+
+// LCMAll returns the least common multiple of all xs, 1 for none.
+func LCMAll(xs ...int64) (int64, error) {
+    l := int64(1)
+    for _, x := range xs {
+        var err error
+        if l, err = LCM(l, x); err != nil {
+            return 0, err
+        }
+    }
+    return l, nil
+}
+
+// BigGCD returns the non-negative greatest common divisor of a and b.
+func BigGCD(a *big.Int, b *big.Int) *big.Int {
+    return new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+}
+
+// BigLCM returns the non-negative least common multiple of all xs, 1 for none.
+func BigLCM(xs ...*big.Int) *big.Int {
+    l := big.NewInt(1)
+    for _, x := range xs {
+        if x.Sign() == 0 {
+            return new(big.Int)
+        }
+        g := BigGCD(l, x)
+        l.Mul(l.Quo(l, g), new(big.Int).Abs(x))
+    }
+    return l
+}
+
+// ExtendedGCD returns g = GCD(a, b) and Bézout coefficients x and y with
+// a*x + b*y = g.
+func ExtendedGCD(a int64, b int64) (int64, int64, int64) {
+    oldR, r := a, b
+    oldX, x := int64(1), int64(0)
+    oldY, y := int64(0), int64(1)
+    for r != 0 {
+        q := oldR / r
+        oldR, r = r, oldR - q * r
+        oldX, x = x, oldX - q * x
+        oldY, y = y, oldY - q * y
+    }
+    if oldR < 0 {
+        return -oldR, -oldX, -oldY
+    }
+    return oldR, oldX, oldY
+}
+
+// CRT solves the system x ≡ remainders[i] (mod moduli[i]) for moduli that
+// need not be coprime. It returns the smallest non-negative solution x
+// and the modulus of all solutions, the lcm of the moduli, or
+// ErrNoSolution if the congruences contradict each other.
+func CRT(remainders []int64, moduli []int64) (int64, int64, error) {
+    if len(remainders) != len(moduli) {
+        return 0, 0, errors.New("mathx: remainders and moduli differ in length")
+    }
+    bigRemainders := make([]*big.Int, len(remainders))
+    bigModuli := make([]*big.Int, len(moduli))
+    for i := range remainders {
+        bigRemainders[i] = big.NewInt(remainders[i])
+        bigModuli[i] = big.NewInt(moduli[i])
+    }
+    x, m, err := BigCRT(bigRemainders, bigModuli)
+    if err != nil {
+        return 0, 0, err
+    }
+    if !m.IsInt64() {
+        return 0, 0, ErrOverflow
+    }
+    return x.Int64(), m.Int64(), nil
+}
+
+// BigCRT is CRT for *big.Int.
+func BigCRT(remainders []*big.Int, moduli []*big.Int) (*big.Int, *big.Int, error) {
+    if len(remainders) != len(moduli) {
+        return nil, nil, errors.New("mathx: remainders and moduli differ in length")
+    }
+    x := new(big.Int)
+    m := big.NewInt(1)
+    for i := range moduli {
+        if moduli[i].Sign() == 0 {
+            return nil, nil, errors.New("mathx: modulus 0")
+        }
+        mi := new(big.Int).Abs(moduli[i])
+        ri := new(big.Int).Mod(remainders[i], mi)
+        // x + m*k ≡ ri (mod mi)  <=>  m*k ≡ ri - x (mod mi)
+        g := new(big.Int)
+        inverse := new(big.Int)
+        g.GCD(inverse, nil, m, mi)
+        diff := new(big.Int).Sub(ri, x)
+        if new(big.Int).Mod(diff, g).Sign() != 0 {
+            return nil, nil, ErrNoSolution
+        }
+        step := new(big.Int).Quo(mi, g)
+        k := new(big.Int).Quo(diff, g)
+        k.Mul(k, inverse).Mod(k, step)
+        x.Add(x, k.Mul(k, m))
+        m.Mul(m, step)
+        x.Mod(x, m)
+    }
+    return x, m, nil
+}
@@ -0,0 +1,80 @@
+package mathx
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestGCDAndLCM(t *testing.T) {
+    tests := []struct {
+        a, b, gcd, lcm int64
+    }{
+        {0, 0, 0, 0},
+        {0, 7, 7, 0},
+        {12, 18, 6, 36},
+        {-12, 18, 6, 36},
+        {13, 17, 1, 221},
+        {math.MaxInt64, 1, 1, math.MaxInt64},
+    }
+    for _, test := range tests {
+        if got := GCD(test.a, test.b); got != test.gcd {
+            t.Errorf("GCD(%d, %d) = %d, want %d", test.a, test.b, got, test.gcd)
+        }
+        if got, err := LCM(test.a, test.b); err != nil || got != test.lcm {
+            t.Errorf("LCM(%d, %d) = %d, %v, want %d", test.a, test.b, got, err, test.lcm)
+        }
+    }
+    if _, err := LCM(math.MaxInt64, math.MaxInt64 - 1); !errors.Is(err, ErrOverflow) {
+        t.Errorf("LCM overflow: err = %v", err)
+    }
+}
+
+func TestLCMAll(t *testing.T) {
+    // pairwise overlapping factors 2·3, 2·5, 3·5 and a large prime
+    steps := []int64{6, 10, 15, 1000000007}
+    got, err := LCMAll(steps...)
+    if err != nil || got != 30000000210 {
+        t.Errorf("LCMAll = %d, %v", got, err)
+    }
+    bigSteps := make([]*big.Int, len(steps))
+    for i, s := range steps {
+        bigSteps[i] = big.NewInt(s)
+    }
+    if got := BigLCM(bigSteps...); got.Int64() != 30000000210 {
+        t.Errorf("BigLCM = %s", got)
+    }
+}
+
+func TestExtendedGCD(t *testing.T) {
+    for _, pair := range [][2]int64{{240, 46}, {-240, 46}, {0, 5}, {17, 0}, {7, 13}} {
+        a, b := pair[0], pair[1]
+        g, x, y := ExtendedGCD(a, b)
+        if g != GCD(a, b) || a * x + b * y != g {
+            t.Errorf("ExtendedGCD(%d, %d) = %d, %d, %d", a, b, g, x, y)
+        }
+    }
+}
+
+func TestCRT(t *testing.T) {
+    tests := []struct {
+        remainders, moduli []int64
+        x, m int64
+        err error
+    }{
+        {[]int64{2, 3, 2}, []int64{3, 5, 7}, 23, 105, nil},
+        // not coprime, but consistent
+        {[]int64{3, 5}, []int64{4, 6}, 11, 12, nil},
+        {[]int64{-1, 0}, []int64{10, 3}, 9, 30, nil},
+        {[]int64{1, 2}, []int64{4, 6}, 0, 0, ErrNoSolution},
+        {nil, nil, 0, 1, nil},
+    }
+    for _, test := range tests {
+        x, m, err := CRT(test.remainders, test.moduli)
+        if !errors.Is(err, test.err) || x != test.x || m != test.m {
+            t.Errorf("CRT(%v, %v) = %d, %d, %v, want %d, %d, %v",
+                test.remainders, test.moduli, x, m, err, test.x, test.m, test.err)
+        }
+    }
+}
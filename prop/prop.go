@@ -0,0 +1,45 @@
+// Package prop runs property tests, comparing a solver with a slow but
+// obviously correct reference on many small random inputs.
+//
+// A failure names the seed of its input, rerun just that one with e.g.
+//
+//	go test ./days/day12 -run TestReference -prop.seed 1234
+package prop
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+)
+
+// COUNT is the number of inputs checked by default, a tenth of it with
+// go test -short
+const COUNT = 2000
+
+var (
+    count = flag.Int("prop.count", COUNT, "random inputs per property")
+    seed = flag.Int64("prop.seed", 0, "check only the input of this seed")
+)
+
+// Check calls property with generators seeded 1 to -prop.count, or
+// -prop.seed only, and fails on the first error it returns.
+func Check(t testing.TB, property func(rng *rand.Rand) error) {
+    t.Helper()
+    seeds := []int64{}
+    if *seed != 0 {
+        seeds = append(seeds, *seed)
+    } else {
+        n := *count
+        if testing.Short() {
+            n = max(n / 10, 1)
+        }
+        for s := int64(1); s <= int64(n); s++ {
+            seeds = append(seeds, s)
+        }
+    }
+    for _, s := range seeds {
+        if err := property(rand.New(rand.NewSource(s))); err != nil {
+            t.Fatalf("seed %d: %v", s, err)
+        }
+    }
+}
@@ -0,0 +1,46 @@
+package prop
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// recorder keeps the failure instead of failing the test
+type recorder struct {
+    testing.TB
+    msg string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+    r.msg = fmt.Sprintf(format, args...)
+}
+
+func TestCheck(t *testing.T) {
+    calls := 0
+    Check(t, func(rng *rand.Rand) error {
+        calls++
+        return nil
+    })
+    if want := *count; testing.Short() {
+        if calls != want / 10 {
+            t.Errorf("checked %d inputs, want %d", calls, want / 10)
+        }
+    } else if calls != want {
+        t.Errorf("checked %d inputs, want %d", calls, want)
+    }
+
+    r := &recorder{TB: t}
+    Check(r, func(rng *rand.Rand) error {
+        if n := rng.Intn(10); n == 0 {
+            return fmt.Errorf("got %d", n)
+        }
+        return nil
+    })
+    if !strings.HasPrefix(r.msg, "seed ") || !strings.HasSuffix(r.msg, ": got 0") {
+        t.Errorf("failure = %q", r.msg)
+    }
+}
@@ -0,0 +1,48 @@
+package puzzle
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParseError reports where a puzzle input is malformed. Line and Column
+// are 1-based, Line is 0 while the error has not left the line parser.
+type ParseError struct {
+    Line int
+    Column int
+    Text string
+    Msg string
+    Err error
+}
+
+func (e *ParseError) Error() string {
+    msg := e.Msg
+    if e.Text != "" {
+        msg = fmt.Sprintf("%s '%s'", msg, e.Text)
+    }
+    if e.Err != nil {
+        msg = fmt.Sprintf("%s: %s", msg, e.Err)
+    }
+    if e.Line == 0 {
+        return fmt.Sprintf("column %d: %s", e.Column, msg)
+    }
+    return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, msg)
+}
+
+func (e *ParseError) Unwrap() error {
+    return e.Err
+}
+
+// Errorf returns a ParseError for text found at column of the line being parsed.
+func Errorf(column int, text string, format string, args ...any) *ParseError {
+    return &ParseError{Column: column, Text: text, Msg: fmt.Sprintf(format, args...)}
+}
+
+// AtLine attaches the line number to a ParseError returned by a line parser.
+func AtLine(err error, line int) error {
+    var parseErr *ParseError
+    if errors.As(err, &parseErr) && parseErr.Line == 0 {
+        parseErr.Line = line
+    }
+    return err
+}
@@ -0,0 +1,53 @@
+package puzzle
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Field is a part of a line together with the column it starts at.
+type Field struct {
+    Text string
+    Column int
+}
+
+// SplitFields splits line at every sep like strings.Split, keeping track
+// of the column every part starts at.
+func SplitFields(line string, sep string) []Field {
+    parts := strings.Split(line, sep)
+    fields := make([]Field, len(parts))
+    column := 1
+    for i, part := range parts {
+        fields[i] = Field{Text: part, Column: column}
+        column += utf8.RuneCountInString(part) + utf8.RuneCountInString(sep)
+    }
+    return fields
+}
+
+// Atoi converts the field to an int, reporting a ParseError at its column.
+func (f Field) Atoi() (int, error) {
+    n, err := strconv.Atoi(f.Text)
+    if numErr, ok := err.(*strconv.NumError); ok {
+        err = numErr.Err
+    }
+    if err != nil {
+        return 0, &ParseError{Column: f.Column, Text: f.Text, Msg: "invalid number", Err: err}
+    }
+    return n, nil
+}
+
+// Errorf returns a ParseError pointing at the field.
+func (f Field) Errorf(format string, args ...any) *ParseError {
+    return Errorf(f.Column, f.Text, format, args...)
+}
+
+// Split splits the field at every sep like SplitFields, the columns of
+// the parts stay relative to the whole line.
+func (f Field) Split(sep string) []Field {
+    fields := SplitFields(f.Text, sep)
+    for i := range fields {
+        fields[i].Column += f.Column - 1
+    }
+    return fields
+}
@@ -0,0 +1,36 @@
+package puzzle
+
+import (
+	"context"
+	"fmt"
+)
+
+// CHECK_EVERY is how many iterations of a cheap hot loop may pass
+// between checks of the context.
+const CHECK_EVERY = 1 << 12
+
+// Interrupted is returned by a solver stopped by its context while
+// solving Part. The Result returned with it holds the parts before.
+type Interrupted struct {
+    Part int
+    // Progress tells how far the solver got, e.g. "1234 of 1000000000 cycles"
+    Progress string
+    Err error
+}
+
+func (e *Interrupted) Error() string {
+    return fmt.Sprintf("part %d interrupted after %s: %s", e.Part, e.Progress, e.Err)
+}
+
+func (e *Interrupted) Unwrap() error {
+    return e.Err
+}
+
+// Interrupt returns an *Interrupted error for part if ctx is done, nil
+// otherwise.
+func Interrupt(ctx context.Context, part int, format string, args ...any) error {
+    if err := ctx.Err(); err != nil {
+        return &Interrupted{part, fmt.Sprintf(format, args...), err}
+    }
+    return nil
+}
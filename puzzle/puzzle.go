@@ -0,0 +1,39 @@
+// Package puzzle defines what every day's solver has in common.
+package puzzle
+
+import (
+	"context"
+	"io"
+	"math/rand"
+)
+
+// Result holds the answers to both parts of a day's puzzle.
+type Result struct {
+    Part1 int
+    Part2 int
+}
+
+// Part returns the answer to part 1 or 2.
+func (r Result) Part(part int) int {
+    if part == 1 {
+        return r.Part1
+    }
+    return r.Part2
+}
+
+// Solver solves both parts of a day's puzzle from its input. It stops
+// with an *Interrupted error once ctx is done.
+type Solver interface {
+    Solve(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// SolverFunc adapts a plain solve function to the Solver interface.
+type SolverFunc func(ctx context.Context, r io.Reader) (Result, error)
+
+func (f SolverFunc) Solve(ctx context.Context, r io.Reader) (Result, error) {
+    return f(ctx, r)
+}
+
+// Generator returns a random input the day's solver accepts, about size
+// lines or, for grids, size rows and columns.
+type Generator func(rng *rand.Rand, size int) []byte
@@ -0,0 +1,32 @@
+package puzzle
+
+import (
+	"bufio"
+	"io"
+)
+
+// Scanner reads an input line by line like bufio.Scanner and keeps
+// track of the current line number for error reporting.
+type Scanner struct {
+    *bufio.Scanner
+    Line int
+}
+
+func NewScanner(r io.Reader) *Scanner {
+    return &Scanner{Scanner: bufio.NewScanner(r)}
+}
+
+func (s *Scanner) Scan() bool {
+    if !s.Scanner.Scan() {
+        return false
+    }
+    s.Line++
+    return true
+}
+
+// Errorf returns a ParseError located on the current line.
+func (s *Scanner) Errorf(column int, text string, format string, args ...any) error {
+    err := Errorf(column, text, format, args...)
+    err.Line = s.Line
+    return err
+}
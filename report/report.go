@@ -0,0 +1,80 @@
+// Package report prints solutions in a machine-readable form.
+package report
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/input"
+)
+
+const (
+    TEXT Format = "text"
+    JSON Format = "json"
+)
+
+// Format is the output format of a runner, usable as a flag.
+type Format string
+
+func (f *Format) String() string {
+    return string(*f)
+}
+
+func (f *Format) Set(value string) error {
+    switch Format(value) {
+    case TEXT, JSON:
+        *f = Format(value)
+        return nil
+    }
+    return fmt.Errorf("unknown format '%s', expected %s or %s", value, TEXT, JSON)
+}
+
+// RegisterFlag adds -format to flags.
+func RegisterFlag(flags *flag.FlagSet) *Format {
+    f := TEXT
+    flags.Var(&f, "format", "output format, text or json")
+    return &f
+}
+
+// Entry is the answer to one part. Duration is in nanoseconds and, as
+// both parts are solved at once, the same for both of them.
+type Entry struct {
+    Day int `json:"day"`
+    Part int `json:"part"`
+    Answer int `json:"answer"`
+    Duration time.Duration `json:"duration"`
+    InputSHA string `json:"input_sha"`
+}
+
+// Entries returns the entries of the given parts of solution, of both
+// parts if none are given.
+func Entries(solution *input.Solution, parts ...int) []Entry {
+    if len(parts) == 0 {
+        parts = []int{1, 2}
+    }
+    entries := make([]Entry, len(parts))
+    for i, part := range parts {
+        entries[i] = Entry{
+            solution.Source.Day,
+            part,
+            solution.Result.Part(part),
+            solution.Duration,
+            solution.Source.SHA256,
+        }
+    }
+    return entries
+}
+
+// WriteJSON writes one JSON object per line for each entry.
+func WriteJSON(w io.Writer, entries []Entry) error {
+    encoder := json.NewEncoder(w)
+    for _, entry := range entries {
+        if err := encoder.Encode(entry); err != nil {
+            return err
+        }
+    }
+    return nil
+}
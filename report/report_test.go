@@ -0,0 +1,41 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"stefanvonderkrone/adventOfCode2023/input"
+	"stefanvonderkrone/adventOfCode2023/puzzle"
+)
+
+func TestFormat(t *testing.T) {
+    var f Format
+    if err := f.Set("json"); err != nil || f != JSON {
+        t.Errorf("Set(json) = %s, %v", f, err)
+    }
+    if err := f.Set("xml"); err == nil || f != JSON {
+        t.Errorf("Set(xml) = %s, %v", f, err)
+    }
+}
+
+func TestWriteJSON(t *testing.T) {
+    solution := &input.Solution{
+        Source: &input.Source{Day: 7, SHA256: "abc"},
+        Result: puzzle.Result{Part1: 6440, Part2: 5905},
+        Duration: 3 * time.Millisecond,
+    }
+    var b bytes.Buffer
+    if err := WriteJSON(&b, Entries(solution)); err != nil {
+        t.Fatal(err)
+    }
+    want := `{"day":7,"part":1,"answer":6440,"duration":3000000,"input_sha":"abc"}
+{"day":7,"part":2,"answer":5905,"duration":3000000,"input_sha":"abc"}
+`
+    if b.String() != want {
+        t.Errorf("WriteJSON =\n%s\nwant\n%s", b.String(), want)
+    }
+    if entries := Entries(solution, 2); len(entries) != 1 || entries[0].Answer != 5905 {
+        t.Errorf("Entries(2) = %+v", entries)
+    }
+}